@@ -0,0 +1,64 @@
+package abis
+
+import (
+	"sort"
+
+	"github.com/txpull/unpack/types"
+)
+
+// MethodCandidate represents the method candidate that successfully decoded the calldata.
+type MethodCandidate struct {
+	Method    *types.Method
+	Arguments []Value
+}
+
+// RankMethodCandidates decodes calldata arguments (without the selector) against every method sharing
+// the selector. Candidates whose arguments fail to decode, or do not consume the calldata exactly
+// (trailing bytes), are rejected. Remaining candidates are ranked by likelihood: methods originating
+// from verified sources first, followed by 4byte methods ordered by their 4byte id (older first).
+func RankMethodCandidates(candidates types.Methods, data []byte) []MethodCandidate {
+	toReturn := make([]MethodCandidate, 0, len(candidates))
+
+	for _, method := range candidates {
+		inputs, err := method.GetABIArguments()
+		if err != nil {
+			continue
+		}
+
+		values, err := inputs.UnpackValues(data)
+		if err != nil {
+			continue
+		}
+
+		// Re-encoding the decoded values yields the canonical encoding. Anything longer
+		// than that means the calldata holds data the candidate does not account for.
+		packed, err := inputs.Pack(values...)
+		if err != nil || len(packed) != len(data) {
+			continue
+		}
+
+		arguments := make([]Value, 0, len(values))
+		for i, value := range values {
+			arguments = append(arguments, NewValue(inputs[i].Name, inputs[i].Type, value))
+		}
+
+		toReturn = append(toReturn, MethodCandidate{Method: method, Arguments: arguments})
+	}
+
+	sort.SliceStable(toReturn, func(i, j int) bool {
+		a, b := toReturn[i].Method, toReturn[j].Method
+
+		if a.IsPartial != b.IsPartial {
+			return !a.IsPartial
+		}
+
+		// Methods with unknown 4byte id go last.
+		if (a.FourByteID == 0) != (b.FourByteID == 0) {
+			return a.FourByteID != 0
+		}
+
+		return a.FourByteID < b.FourByteID
+	})
+
+	return toReturn
+}
@@ -0,0 +1,42 @@
+package abis
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/types"
+)
+
+func TestRankMethodCandidates(t *testing.T) {
+	tAssert := assert.New(t)
+
+	candidates := types.Methods{
+		{Signature: "foo(uint256)", IsPartial: true, FourByteID: 1, Arguments: []types.MethodArgument{{Type: "uint256"}}},
+		{Signature: "pair(uint256,uint256)", IsPartial: true, FourByteID: 0, Arguments: []types.MethodArgument{{Type: "uint256"}, {Type: "uint256"}}},
+		{Signature: "toggle(address,bool)", IsPartial: true, FourByteID: 7, Arguments: []types.MethodArgument{{Type: "address"}, {Type: "bool"}}},
+		{Signature: "bar(address,uint256,uint256)", IsPartial: true, FourByteID: 2, Arguments: []types.MethodArgument{{Type: "address"}, {Type: "uint256"}, {Type: "uint256"}}},
+		{Signature: "transfer(address,uint256)", IsPartial: true, FourByteID: 9, Arguments: []types.MethodArgument{{Type: "address"}, {Type: "uint256"}}},
+		{Signature: "transfer(address,uint256)", IsPartial: false, Arguments: []types.MethodArgument{{Name: "to", Type: "address"}, {Name: "amount", Type: "uint256"}}},
+	}
+
+	method := &types.Method{Arguments: candidates[5].Arguments}
+	arguments, err := method.GetABIArguments()
+	tAssert.NoError(err)
+
+	data, err := arguments.Pack(common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE"), big.NewInt(42))
+	tAssert.NoError(err)
+
+	ranked := RankMethodCandidates(candidates, data)
+
+	signatures := make([]string, 0, len(ranked))
+	for _, candidate := range ranked {
+		signatures = append(signatures, candidate.Method.Signature)
+	}
+
+	// foo leaves trailing bytes, bar is too short and toggle has malformed bool.
+	tAssert.Equal([]string{"transfer(address,uint256)", "transfer(address,uint256)", "pair(uint256,uint256)"}, signatures)
+	tAssert.False(ranked[0].Method.IsPartial)
+	tAssert.Equal("to", ranked[0].Arguments[0].Name)
+}
@@ -0,0 +1,61 @@
+package abis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/txpull/unpack/opcodes"
+)
+
+var (
+	// ErrConstructorNotFound is returned when the ABI does not define the constructor or it takes no arguments.
+	ErrConstructorNotFound = errors.New("constructor with arguments not found in abi")
+
+	// ErrConstructorArgumentsNotFound is returned when constructor arguments could not be located in the creation input.
+	ErrConstructorArgumentsNotFound = errors.New("constructor arguments not found in creation input")
+)
+
+// DecodeConstructorArguments decodes ABI encoded constructor arguments against the decoder ABI constructor.
+func (d *Decoder) DecodeConstructorArguments(data []byte) ([]Value, error) {
+	return DecodeConstructorArguments(d.abi.Constructor.Inputs, data)
+}
+
+// DecodeConstructorArguments decodes ABI encoded constructor arguments into named, typed values.
+func DecodeConstructorArguments(inputs abi.Arguments, data []byte) ([]Value, error) {
+	if len(inputs) == 0 {
+		return nil, ErrConstructorNotFound
+	}
+
+	arguments, err := DecodeArguments(inputs, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack constructor arguments: %w", err)
+	}
+
+	return arguments, nil
+}
+
+// ExtractConstructorArguments extracts ABI encoded constructor arguments appended to the creation
+// bytecode in the contract creation input.
+//
+// When the compiled creation bytecode is known, it is simply stripped from the input. Otherwise
+// the end of the creation bytecode is located through the CBOR metadata trailer of the deployed
+// runtime bytecode, which the compiler embeds unchanged at the end of the creation bytecode.
+func ExtractConstructorArguments(input []byte, bytecode []byte, runtimeBytecode []byte) ([]byte, error) {
+	if len(bytecode) > 0 && bytes.HasPrefix(input, bytecode) {
+		return input[len(bytecode):], nil
+	}
+
+	metadata, err := opcodes.ParseMetadata(runtimeBytecode)
+	if err != nil {
+		return nil, ErrConstructorArgumentsNotFound
+	}
+
+	index := bytes.LastIndex(input, metadata.Raw)
+	if index < 0 {
+		return nil, ErrConstructorArgumentsNotFound
+	}
+
+	return input[index+len(metadata.Raw):], nil
+}
@@ -0,0 +1,49 @@
+package abis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const constructorTestAbi = `[{"type":"constructor","inputs":[{"name":"token","type":"address"},{"name":"fee","type":"uint256"}]}]`
+
+func TestExtractAndDecodeConstructorArguments(t *testing.T) {
+	tAssert := assert.New(t)
+
+	// a1 65 "bzzr0" 58 20 <32 bytes> 0029
+	metadata := common.FromHex("0xa165627a7a72305820" + strings.Repeat("ab", 32) + "0029")
+	runtime := append(common.FromHex("0x6080604052600080fd"), metadata...)
+	creation := append(common.FromHex("0x6080604052348015600f57600080fd5b50603f80601d6000396000f3fe"), runtime...)
+
+	arguments := common.FromHex(
+		"000000000000000000000000bebebebebebebebebebebebebebebebebebebebe" +
+			"00000000000000000000000000000000000000000000000000000000000001f4")
+
+	data, err := ExtractConstructorArguments(append(creation, arguments...), nil, runtime)
+	tAssert.NoError(err)
+	tAssert.Equal(arguments, data)
+
+	data, err = ExtractConstructorArguments(append(creation, arguments...), creation, nil)
+	tAssert.NoError(err)
+	tAssert.Equal(arguments, data)
+
+	_, err = ExtractConstructorArguments(creation, nil, common.FromHex("0x6080604052"))
+	tAssert.ErrorIs(err, ErrConstructorArgumentsNotFound)
+
+	parsedAbi, err := abi.JSON(strings.NewReader(constructorTestAbi))
+	tAssert.NoError(err)
+
+	decoded, err := DecodeConstructorArguments(parsedAbi.Constructor.Inputs, data)
+	tAssert.NoError(err)
+	tAssert.Len(decoded, 2)
+	tAssert.Equal("token", decoded[0].Name)
+	tAssert.Equal(common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe"), decoded[0].Value)
+	tAssert.Equal("uint256", decoded[1].Type)
+
+	_, err = DecodeConstructorArguments(nil, data)
+	tAssert.ErrorIs(err, ErrConstructorNotFound)
+}
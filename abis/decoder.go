@@ -2,13 +2,60 @@ package abis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/txpull/unpack/readers"
 )
 
+var (
+	// ErrCalldataTooShort is returned when calldata is too short to contain the method selector.
+	ErrCalldataTooShort = errors.New("calldata too short to contain method selector")
+
+	// ErrMethodNotFound is returned when the method is not defined in the ABI.
+	ErrMethodNotFound = errors.New("method not found in abi")
+
+	// ErrEventNotFound is returned when the event is not defined in the ABI.
+	ErrEventNotFound = errors.New("event not found in abi")
+
+	// ErrAnonymousLog is returned when the log has no topics and therefore no event hash to match against.
+	ErrAnonymousLog = errors.New("log has no topics")
+)
+
+// Input represents decoded method calldata.
+type Input struct {
+	// Selector is the hex encoded 4 byte method selector without 0x prefix.
+	Selector string `json:"selector"`
+
+	// Name is the name of the method.
+	Name string `json:"name"`
+
+	// Signature is the canonical method signature, e.g. transfer(address,uint256).
+	Signature string `json:"signature"`
+
+	// Arguments holds decoded method arguments.
+	Arguments []Value `json:"arguments"`
+}
+
+// Event represents decoded event log.
+type Event struct {
+	// Hash is the event topic hash (topics[0]).
+	Hash common.Hash `json:"hash"`
+
+	// Name is the name of the event.
+	Name string `json:"name"`
+
+	// Signature is the canonical event signature, e.g. Transfer(address,address,uint256).
+	Signature string `json:"signature"`
+
+	// Arguments holds decoded indexed and non-indexed arguments in ABI order.
+	Arguments []Value `json:"arguments"`
+}
+
 type Decoder struct {
 	ctx    context.Context
 	reader *readers.Manager
@@ -40,3 +87,75 @@ func (d *Decoder) DecodeRawAbi() error {
 
 	return nil
 }
+
+// DecodeInput decodes the method calldata (selector followed by ABI encoded arguments).
+func (d *Decoder) DecodeInput(calldata []byte) (*Input, error) {
+	if len(calldata) < 4 {
+		return nil, ErrCalldataTooShort
+	}
+
+	method, err := d.abi.MethodById(calldata[:4])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMethodNotFound, common.Bytes2Hex(calldata[:4]))
+	}
+
+	arguments, err := DecodeArguments(method.Inputs, calldata[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s arguments: %w", method.Sig, err)
+	}
+
+	return &Input{
+		Selector:  common.Bytes2Hex(method.ID),
+		Name:      method.Name,
+		Signature: method.Sig,
+		Arguments: arguments,
+	}, nil
+}
+
+// DecodeOutput decodes data returned by the method. Method is referenced by its (ABI) name.
+func (d *Decoder) DecodeOutput(method string, returndata []byte) ([]Value, error) {
+	abiMethod, ok := d.abi.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMethodNotFound, method)
+	}
+
+	outputs, err := DecodeArguments(abiMethod.Outputs, returndata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s outputs: %w", abiMethod.Sig, err)
+	}
+
+	return outputs, nil
+}
+
+// DecodeLog decodes the log against the ABI event matching its first topic.
+func (d *Decoder) DecodeLog(log types.Log) (*Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrAnonymousLog
+	}
+
+	event, err := d.abi.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, log.Topics[0].Hex())
+	}
+
+	arguments, err := DecodeEventArguments(event.Inputs, log.Topics[1:], log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s arguments: %w", event.Sig, err)
+	}
+
+	return &Event{
+		Hash:      event.ID,
+		Name:      event.Name,
+		Signature: event.Sig,
+		Arguments: arguments,
+	}, nil
+}
+
+// EncodeCall packs the method selector and arguments into calldata. Method is referenced by its (ABI) name.
+func (d *Decoder) EncodeCall(method string, args ...interface{}) ([]byte, error) {
+	if _, ok := d.abi.Methods[method]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMethodNotFound, method)
+	}
+
+	return d.abi.Pack(method, args...)
+}
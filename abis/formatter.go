@@ -0,0 +1,318 @@
+package abis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/types"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidDecimals is returned when the token decimals() call returns malformed data.
+var ErrInvalidDecimals = errors.New("invalid token decimals")
+
+// decimalsSelector is the selector of the ERC-20 decimals() method.
+var decimalsSelector = common.FromHex("0x313ce567")
+
+// FormattedValue represents the human readable version of the decoded Value.
+type FormattedValue struct {
+	// Name is the name of the argument.
+	Name string `json:"name,omitempty"`
+
+	// Type is the solidity type of the argument.
+	Type string `json:"type"`
+
+	// Value holds the formatted value: strings for scalars (except bool), ordered JSON objects
+	// for tuples and JSON arrays for arrays and slices.
+	Value interface{} `json:"value"`
+
+	// Amount holds the token amount scaled by the token decimals, e.g. 1.5 for 1500000000000000000 wei.
+	Amount string `json:"amount,omitempty"`
+}
+
+// Object represents the tuple rendered as the JSON object preserving the ABI field order.
+type Object []FormattedValue
+
+// MarshalJSON renders tuple fields as the JSON object. Unnamed fields are keyed by their index.
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name := field.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Formatter renders decoded values in the human readable form and scales token amounts by the
+// ERC-20 decimals. Decimals are fetched from the chain and cached in redis.
+type Formatter struct {
+	ctx       context.Context
+	ethClient *clients.EthClient
+	redis     *clients.Redis
+}
+
+// FormatterOption defines a function type that applies configurations to a Formatter.
+type FormatterOption func(*Formatter)
+
+func WithEthClient(client *clients.EthClient) FormatterOption {
+	return func(f *Formatter) {
+		f.ethClient = client
+	}
+}
+
+func WithRedis(client *clients.Redis) FormatterOption {
+	return func(f *Formatter) {
+		f.redis = client
+	}
+}
+
+// NewFormatter creates the formatter. Eth client is only required for token amount scaling.
+func NewFormatter(ctx context.Context, opts ...FormatterOption) *Formatter {
+	formatter := &Formatter{ctx: ctx}
+
+	for _, opt := range opts {
+		opt(formatter)
+	}
+
+	return formatter
+}
+
+// Format renders values in the human readable form:
+//   - addresses are checksummed,
+//   - integers are decimal strings,
+//   - fixed size bytes are UTF-8 strings when printable and hex otherwise,
+//   - dynamic bytes and hashes are hex,
+//   - tuples are JSON objects and arrays are JSON arrays.
+func (f *Formatter) Format(values []Value) []FormattedValue {
+	toReturn := make([]FormattedValue, 0, len(values))
+
+	for _, value := range values {
+		toReturn = append(toReturn, FormatValue(value))
+	}
+
+	return toReturn
+}
+
+// FormatTokenArguments formats values of the call made to (or event emitted by) the ERC-20 token and
+// scales top level integer arguments that hold token amounts (value, amount, wad, ...) by the token decimals.
+func (f *Formatter) FormatTokenArguments(chainId *big.Int, token common.Address, values []Value) []FormattedValue {
+	toReturn := f.Format(values)
+
+	decimals, err := f.Decimals(chainId, token)
+	if err != nil {
+		zap.L().Debug(
+			"failed to get token decimals",
+			zap.String("token", token.Hex()),
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Error(err),
+		)
+		return toReturn
+	}
+
+	for i, value := range values {
+		amount, ok := value.Value.(*big.Int)
+		if !ok || amount == nil || !isAmountArgument(value.Name) {
+			continue
+		}
+
+		toReturn[i].Amount = ScaleAmount(amount, decimals)
+	}
+
+	return toReturn
+}
+
+// FormatAmount scales the raw token amount by the token decimals.
+func (f *Formatter) FormatAmount(chainId *big.Int, token common.Address, amount *big.Int) (string, error) {
+	decimals, err := f.Decimals(chainId, token)
+	if err != nil {
+		return "", err
+	}
+
+	return ScaleAmount(amount, decimals), nil
+}
+
+// Decimals returns the ERC-20 token decimals. Values are cached in redis (when set) without expiration
+// as decimals of the deployed token never change.
+func (f *Formatter) Decimals(chainId *big.Int, token common.Address) (uint8, error) {
+	key := types.GetTokenDecimalsStorageKey(chainId, token)
+
+	if f.redis != nil {
+		if cached, err := f.redis.Get(f.ctx, key); err == nil {
+			if decimals, err := strconv.ParseUint(string(cached), 10, 8); err == nil {
+				return uint8(decimals), nil
+			}
+		}
+	}
+
+	if f.ethClient == nil {
+		return 0, errors.New("eth client is required to fetch token decimals")
+	}
+
+	result, err := helpers.CallContract(f.ctx, f.ethClient, token, decimalsSelector, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call decimals(): %w", err)
+	}
+
+	if len(result) != common.HashLength {
+		return 0, ErrInvalidDecimals
+	}
+
+	decimals := new(big.Int).SetBytes(result)
+	if !decimals.IsUint64() || decimals.Uint64() > 255 {
+		return 0, ErrInvalidDecimals
+	}
+
+	if f.redis != nil {
+		if err := f.redis.Write(f.ctx, key, decimals.String(), 0); err != nil {
+			zap.L().Error(
+				"failed to write token decimals to redis",
+				zap.String("token", token.Hex()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return uint8(decimals.Uint64()), nil
+}
+
+// FormatValue renders the single value in the human readable form. See Formatter.Format.
+func FormatValue(value Value) FormattedValue {
+	return FormattedValue{
+		Name:  value.Name,
+		Type:  value.Type,
+		Value: formatValue(value),
+	}
+}
+
+func formatValue(value Value) interface{} {
+	if value.Value == nil {
+		// Arrays of tuples are arrays first, hence the order of checks.
+		if strings.HasSuffix(value.Type, "]") {
+			array := make([]interface{}, 0, len(value.Components))
+			for _, component := range value.Components {
+				array = append(array, formatValue(component))
+			}
+			return array
+		}
+
+		if strings.HasPrefix(value.Type, "(") {
+			object := make(Object, 0, len(value.Components))
+			for _, component := range value.Components {
+				object = append(object, FormatValue(component))
+			}
+			return object
+		}
+
+		return nil
+	}
+
+	switch v := value.Value.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case hexutil.Bytes:
+		if strings.HasPrefix(value.Type, "bytes") && value.Type != "bytes" {
+			if text, ok := printable(v); ok {
+				return text
+			}
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// ScaleAmount renders the raw integer amount as the decimal number with provided amount of decimals.
+// Trailing fraction zeros are trimmed, e.g. 1500000000000000000 with 18 decimals renders as 1.5.
+func ScaleAmount(amount *big.Int, decimals uint8) string {
+	if decimals == 0 {
+		return amount.String()
+	}
+
+	sign := ""
+	digits := new(big.Int).Abs(amount).String()
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-int(decimals)]
+	fraction := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+
+	if fraction == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + fraction
+}
+
+// printable returns the UTF-8 text stored in the (zero right padded) fixed size bytes, if it is printable.
+func printable(data []byte) (string, bool) {
+	trimmed := bytes.TrimRight(data, "\x00")
+	if len(trimmed) == 0 || !utf8.Valid(trimmed) {
+		return "", false
+	}
+
+	text := string(trimmed)
+	for _, r := range text {
+		if !unicode.IsPrint(r) {
+			return "", false
+		}
+	}
+
+	return text, true
+}
+
+// isAmountArgument reports whether argument name suggests it holds the token amount.
+func isAmountArgument(name string) bool {
+	name = strings.ToLower(strings.TrimLeft(name, "_"))
+
+	switch name {
+	case "value", "wad", "amount", "tokens", "quantity":
+		return true
+	}
+
+	return strings.HasPrefix(name, "amount")
+}
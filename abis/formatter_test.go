@@ -0,0 +1,50 @@
+package abis
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter_Format(t *testing.T) {
+	tAssert := assert.New(t)
+
+	formatter := NewFormatter(context.TODO())
+
+	symbol := make(hexutil.Bytes, 32)
+	copy(symbol, "WBNB")
+
+	values := []Value{
+		{Name: "to", Type: "address", Value: common.HexToAddress("0x33fdd11397bf41ccea71572db4c2ae2f276f84ee")},
+		{Name: "symbol", Type: "bytes32", Value: symbol},
+		{Name: "salt", Type: "bytes4", Value: hexutil.Bytes{0xde, 0xad, 0xbe, 0xef}},
+		{Name: "order", Type: "(uint256,bool[])", Components: []Value{
+			{Name: "amount", Type: "uint256", Value: big.NewInt(42)},
+			{Name: "flags", Type: "bool[]", Components: []Value{{Type: "bool", Value: true}, {Type: "bool", Value: false}}},
+		}},
+	}
+
+	formatted := formatter.Format(values)
+	tAssert.Equal("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE", formatted[0].Value)
+	tAssert.Equal("WBNB", formatted[1].Value)
+	tAssert.Equal("0xdeadbeef", formatted[2].Value)
+
+	raw, err := json.Marshal(formatted[3])
+	tAssert.NoError(err)
+	tAssert.Equal(`{"name":"order","type":"(uint256,bool[])","value":{"amount":"42","flags":[true,false]}}`, string(raw))
+}
+
+func TestFormatter_ScaleAmount(t *testing.T) {
+	tAssert := assert.New(t)
+
+	amount, _ := new(big.Int).SetString("1500000000000000000", 10)
+	tAssert.Equal("1.5", ScaleAmount(amount, 18))
+	tAssert.Equal("0.000042", ScaleAmount(big.NewInt(42), 6))
+	tAssert.Equal("-1", ScaleAmount(big.NewInt(-100), 2))
+	tAssert.Equal("100", ScaleAmount(big.NewInt(100), 0))
+}
@@ -0,0 +1,74 @@
+package abis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/txpull/unpack/readers"
+	"github.com/txpull/unpack/types"
+)
+
+// abiEntry represents a single function entry of the JSON ABI.
+type abiEntry struct {
+	Type            string     `json:"type"`
+	Name            string     `json:"name"`
+	Inputs          []abiParam `json:"inputs"`
+	Outputs         []abiParam `json:"outputs"`
+	StateMutability string     `json:"stateMutability,omitempty"`
+}
+
+type abiParam struct {
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Components []abiParam `json:"components,omitempty"`
+}
+
+// BuildPartialAbi builds the synthetic JSON ABI out of the (partial) methods resolved from the
+// signature database. It is used for unverified contracts where only the method selectors are known.
+func BuildPartialAbi(methods []*types.Method) (string, error) {
+	entries := make([]abiEntry, 0, len(methods))
+
+	for _, method := range methods {
+		entry := abiEntry{
+			Type:            "function",
+			Name:            method.Name,
+			Inputs:          toAbiParams(method.Arguments),
+			Outputs:         toAbiParams(method.Returns),
+			StateMutability: method.StateMutability,
+		}
+
+		entries = append(entries, entry)
+	}
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal partial abi: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+// NewPartialDecoder creates the decoder backed by the synthetic ABI built out of provided methods.
+func NewPartialDecoder(ctx context.Context, r *readers.Manager, methods []*types.Method) (*Decoder, error) {
+	abiRaw, err := BuildPartialAbi(methods)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDecoder(ctx, r, abiRaw)
+}
+
+func toAbiParams(arguments []types.MethodArgument) []abiParam {
+	toReturn := make([]abiParam, 0, len(arguments))
+
+	for _, arg := range arguments {
+		toReturn = append(toReturn, abiParam{
+			Name:       arg.Name,
+			Type:       arg.Type,
+			Components: toAbiParams(arg.Components),
+		})
+	}
+
+	return toReturn
+}
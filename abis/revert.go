@@ -0,0 +1,172 @@
+package abis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/txpull/unpack/readers"
+)
+
+var (
+	// ErrEmptyRevertData is returned when there is no revert data to decode, e.g. `revert()` or out of gas.
+	ErrEmptyRevertData = errors.New("empty revert data")
+
+	// ErrRevertTooShort is returned when revert data is too short to contain error selector.
+	ErrRevertTooShort = errors.New("revert data too short to contain error selector")
+)
+
+var (
+	// errorSelector is the selector of the builtin `Error(string)` used by `require` and `revert("reason")`.
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+
+	// panicSelector is the selector of the builtin `Panic(uint256)` used by compiler inserted checks.
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	stringType, _  = abi.NewType("string", "", nil)
+)
+
+// panicReasons maps solidity panic codes to their description.
+// See: https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "conversion into non-existent enum type",
+	0x22: "access to incorrectly encoded storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to zero-initialized variable of internal function type",
+}
+
+// RevertKind describes which kind of error the revert data carries.
+type RevertKind string
+
+const (
+	RevertKindError   RevertKind = "error"
+	RevertKindPanic   RevertKind = "panic"
+	RevertKindCustom  RevertKind = "custom"
+	RevertKindUnknown RevertKind = "unknown"
+)
+
+// Revert represents decoded revert data of a failed transaction, call or eth_call.
+type Revert struct {
+	Kind        RevertKind `json:"kind"`
+	Selector    string     `json:"selector"`
+	Name        string     `json:"name,omitempty"`
+	Signature   string     `json:"signature,omitempty"`
+	Message     string     `json:"message,omitempty"`
+	PanicCode   *big.Int   `json:"panic_code,omitempty"`
+	PanicReason string     `json:"panic_reason,omitempty"`
+	IsPartial   bool       `json:"is_partial"`
+	Arguments   []Value    `json:"arguments,omitempty"`
+	Data        []byte     `json:"data"`
+}
+
+// PanicReason returns the description of the solidity panic code.
+func PanicReason(code *big.Int) string {
+	if code != nil && code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return "unknown panic code"
+}
+
+// DecodeRevert decodes the revert data. Builtin `Error(string)` and `Panic(uint256)` are always decoded,
+// custom errors are resolved from the contract ABI first (when provided) and then from the errors
+// stored in the readers (when manager is provided). Data that matches none of them is returned with
+// RevertKindUnknown so that callers still have the raw data and selector.
+func DecodeRevert(ctx context.Context, chainId *big.Int, data []byte, contractAbi *abi.ABI, manager *readers.Manager) (*Revert, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyRevertData
+	}
+
+	if len(data) < 4 {
+		return nil, ErrRevertTooShort
+	}
+
+	selector := data[:4]
+	toReturn := &Revert{
+		Kind:     RevertKindUnknown,
+		Selector: common.Bytes2Hex(selector),
+		Data:     data,
+	}
+
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		values, err := abi.Arguments{{Type: stringType}}.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		toReturn.Kind = RevertKindError
+		toReturn.Name = "Error"
+		toReturn.Signature = "Error(string)"
+		toReturn.Message = values[0].(string)
+		return toReturn, nil
+
+	case bytes.Equal(selector, panicSelector):
+		values, err := abi.Arguments{{Type: uint256Type}}.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		toReturn.Kind = RevertKindPanic
+		toReturn.Name = "Panic"
+		toReturn.Signature = "Panic(uint256)"
+		toReturn.PanicCode = values[0].(*big.Int)
+		toReturn.PanicReason = PanicReason(toReturn.PanicCode)
+		return toReturn, nil
+	}
+
+	if contractAbi != nil {
+		var id [4]byte
+		copy(id[:], selector)
+
+		if abiError, err := contractAbi.ErrorByID(id); err == nil {
+			arguments, err := DecodeArguments(abiError.Inputs, data[4:])
+			if err != nil {
+				return nil, err
+			}
+			toReturn.Kind = RevertKindCustom
+			toReturn.Name = abiError.Name
+			toReturn.Signature = abiError.Sig
+			toReturn.Arguments = arguments
+			return toReturn, nil
+		}
+	}
+
+	if manager != nil {
+		customError, err := manager.GetErrorBySignature(ctx, chainId, toReturn.Selector)
+		if err != nil {
+			return toReturn, nil
+		}
+
+		inputs, err := customError.GetABIArguments()
+		if err != nil {
+			return nil, err
+		}
+
+		if arguments, err := DecodeArguments(inputs, data[4:]); err == nil {
+			toReturn.Kind = RevertKindCustom
+			toReturn.Name = customError.Name
+			toReturn.Signature = customError.Signature
+			toReturn.IsPartial = customError.IsPartial
+			toReturn.Arguments = arguments
+		}
+	}
+
+	return toReturn, nil
+}
+
+// DecodeRevert decodes the revert data against the decoder ABI errors, falling back to the
+// errors stored in the decoder readers.
+func (d *Decoder) DecodeRevert(chainId *big.Int, data []byte) (*Revert, error) {
+	return DecodeRevert(d.ctx, chainId, data, &d.abi, d.reader)
+}
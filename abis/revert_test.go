@@ -0,0 +1,66 @@
+package abis
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const revertTestAbi = `[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}]`
+
+func TestDecodeRevert(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+
+	// Error(string) with "Ownable: caller is not the owner" message
+	errorData := common.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572")
+
+	revert, err := DecodeRevert(context.Background(), chainId, errorData, nil, nil)
+	tAssert.NoError(err)
+	tAssert.Equal(RevertKindError, revert.Kind)
+	tAssert.Equal("Ownable: caller is not the owner", revert.Message)
+
+	// Panic(uint256) with arithmetic overflow code
+	panicData := common.FromHex("0x4e487b71" +
+		"0000000000000000000000000000000000000000000000000000000000000011")
+
+	revert, err = DecodeRevert(context.Background(), chainId, panicData, nil, nil)
+	tAssert.NoError(err)
+	tAssert.Equal(RevertKindPanic, revert.Kind)
+	tAssert.Equal(big.NewInt(0x11), revert.PanicCode)
+	tAssert.Equal("arithmetic underflow or overflow", revert.PanicReason)
+
+	// Custom error resolved from the contract ABI
+	contractAbi, err := abi.JSON(strings.NewReader(revertTestAbi))
+	tAssert.NoError(err)
+
+	customError := contractAbi.Errors["InsufficientBalance"]
+	packed, err := customError.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	tAssert.NoError(err)
+
+	revert, err = DecodeRevert(context.Background(), chainId, append(customError.ID[:4], packed...), &contractAbi, nil)
+	tAssert.NoError(err)
+	tAssert.Equal(RevertKindCustom, revert.Kind)
+	tAssert.Equal("InsufficientBalance(uint256,uint256)", revert.Signature)
+	tAssert.Len(revert.Arguments, 2)
+	tAssert.Equal("required", revert.Arguments[1].Name)
+	tAssert.Equal(big.NewInt(2), revert.Arguments[1].Value)
+
+	// Unknown selector is returned as is
+	revert, err = DecodeRevert(context.Background(), chainId, common.FromHex("0xdeadbeef"), &contractAbi, nil)
+	tAssert.NoError(err)
+	tAssert.Equal(RevertKindUnknown, revert.Kind)
+	tAssert.Equal("deadbeef", revert.Selector)
+
+	_, err = DecodeRevert(context.Background(), chainId, nil, nil, nil)
+	tAssert.ErrorIs(err, ErrEmptyRevertData)
+}
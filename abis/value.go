@@ -0,0 +1,159 @@
+package abis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrTopicsMismatch is returned when number of log topics does not match number of indexed event arguments.
+var ErrTopicsMismatch = errors.New("number of topics does not match number of indexed arguments")
+
+// Value represents a single decoded ABI value together with its name and solidity type.
+//
+// Scalars are normalised so that the tree is stable and JSON-serialisable:
+//   - integers of any size are *big.Int (serialised as decimal strings),
+//   - addresses are common.Address,
+//   - bytes, fixed size bytes and function types are hexutil.Bytes,
+//   - indexed dynamic event arguments are the common.Hash of the value,
+//   - bool and string are kept as they are.
+//
+// Arrays, slices and tuples have nil Value and hold their elements (tuple fields) in Components.
+type Value struct {
+	Name       string      `json:"name,omitempty"`
+	Type       string      `json:"type"`
+	Indexed    bool        `json:"indexed,omitempty"`
+	Value      interface{} `json:"value,omitempty"`
+	Components []Value     `json:"components,omitempty"`
+}
+
+// MarshalJSON serialises integers as decimal strings so that values above 2^53 survive JSON consumers.
+func (v Value) MarshalJSON() ([]byte, error) {
+	type value Value
+
+	toMarshal := value(v)
+	if integer, ok := v.Value.(*big.Int); ok && integer != nil {
+		toMarshal.Value = integer.String()
+	}
+
+	return json.Marshal(toMarshal)
+}
+
+// NewValue converts the value unpacked by go-ethereum into the Value tree.
+func NewValue(name string, typ abi.Type, raw interface{}) Value {
+	toReturn := Value{Name: name, Type: typ.String()}
+	rv := reflect.ValueOf(raw)
+
+	switch typ.T {
+	case abi.TupleTy:
+		for i, elem := range typ.TupleElems {
+			toReturn.Components = append(toReturn.Components, NewValue(typ.TupleRawNames[i], *elem, rv.Field(i).Interface()))
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		toReturn.Components = make([]Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			toReturn.Components = append(toReturn.Components, NewValue("", *typ.Elem, rv.Index(i).Interface()))
+		}
+	case abi.IntTy, abi.UintTy:
+		toReturn.Value = toBigInt(rv)
+	case abi.FixedBytesTy, abi.FunctionTy:
+		bytes := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(bytes), rv)
+		toReturn.Value = hexutil.Bytes(bytes)
+	case abi.BytesTy:
+		toReturn.Value = hexutil.Bytes(rv.Bytes())
+	default:
+		toReturn.Value = raw
+	}
+
+	return toReturn
+}
+
+// DecodeArguments unpacks data against provided arguments into the Value tree.
+func DecodeArguments(arguments abi.Arguments, data []byte) ([]Value, error) {
+	values, err := arguments.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make([]Value, 0, len(values))
+	for i, value := range values {
+		toReturn = append(toReturn, NewValue(arguments[i].Name, arguments[i].Type, value))
+	}
+
+	return toReturn, nil
+}
+
+// DecodeEventArguments unpacks indexed arguments from topics (without the event hash topic)
+// and non-indexed arguments from data, returning them in the ABI order.
+func DecodeEventArguments(arguments abi.Arguments, topics []common.Hash, data []byte) ([]Value, error) {
+	var indexed abi.Arguments
+	for _, arg := range arguments {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+
+	if len(indexed) != len(topics) {
+		return nil, ErrTopicsMismatch
+	}
+
+	values, err := arguments.NonIndexed().UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make([]Value, 0, len(arguments))
+	topicIndex, valueIndex := 0, 0
+
+	for _, arg := range arguments {
+		if !arg.Indexed {
+			toReturn = append(toReturn, NewValue(arg.Name, arg.Type, values[valueIndex]))
+			valueIndex++
+			continue
+		}
+
+		topic := topics[topicIndex]
+		topicIndex++
+
+		// Dynamic types are not stored in topics, only the keccak256 hash of their encoding is.
+		switch arg.Type.T {
+		case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+			toReturn = append(toReturn, Value{Name: arg.Name, Type: arg.Type.String(), Indexed: true, Value: topic})
+			continue
+		}
+
+		// Topics are parsed one by one as argument names can be empty or duplicated for partial events.
+		parsed := map[string]interface{}{}
+		if err := abi.ParseTopicsIntoMap(parsed, abi.Arguments{arg}, []common.Hash{topic}); err != nil {
+			return nil, fmt.Errorf("failed to parse topic of argument %q: %w", arg.Name, err)
+		}
+
+		value := NewValue(arg.Name, arg.Type, parsed[arg.Name])
+		value.Indexed = true
+		toReturn = append(toReturn, value)
+	}
+
+	return toReturn, nil
+}
+
+func toBigInt(rv reflect.Value) *big.Int {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint())
+	}
+
+	if integer, ok := rv.Interface().(*big.Int); ok {
+		return integer
+	}
+
+	return nil
+}
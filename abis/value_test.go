@@ -0,0 +1,152 @@
+package abis
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/types"
+)
+
+const valueTestAbi = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"swap","inputs":[{"name":"order","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint128[]"},{"name":"salt","type":"bytes4"}]}],"outputs":[]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func TestDecodeEventArguments(t *testing.T) {
+	tAssert := assert.New(t)
+
+	event := &types.Event{
+		Name:      "Transfer",
+		Signature: "Transfer(address,address,uint256)",
+		Arguments: []types.EventArgument{
+			{Name: "from", Type: "address", Indexed: true},
+			{Name: "to", Type: "address", Indexed: true},
+			{Name: "value", Type: "uint256", Indexed: false},
+		},
+	}
+
+	arguments, err := event.GetABIArguments()
+	tAssert.NoError(err)
+
+	from := common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE")
+	to := common.HexToAddress("0x005D5631EF919DcDa961f0DE1539d62E3f0eBf37")
+
+	data, err := arguments.NonIndexed().Pack(big.NewInt(1000))
+	tAssert.NoError(err)
+
+	topics := []common.Hash{
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}
+
+	decoded, err := DecodeEventArguments(arguments, topics, data)
+	tAssert.NoError(err)
+	tAssert.Len(decoded, 3)
+	tAssert.Equal(from, decoded[0].Value)
+	tAssert.True(decoded[0].Indexed)
+	tAssert.Equal(to, decoded[1].Value)
+	tAssert.Equal(big.NewInt(1000), decoded[2].Value)
+	tAssert.False(decoded[2].Indexed)
+
+	// ERC721 Transfer shares the same topic hash but has all three arguments indexed.
+	_, err = DecodeEventArguments(arguments, append(topics, common.BigToHash(big.NewInt(1))), nil)
+	tAssert.ErrorIs(err, ErrTopicsMismatch)
+}
+
+func TestDecodeArguments(t *testing.T) {
+	tAssert := assert.New(t)
+
+	method := &types.Method{
+		Name:      "transfer",
+		Signature: "transfer(address,uint256)",
+		Arguments: []types.MethodArgument{
+			{Name: "to", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+		},
+	}
+
+	arguments, err := method.GetABIArguments()
+	tAssert.NoError(err)
+
+	to := common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE")
+	data, err := arguments.Pack(to, big.NewInt(42))
+	tAssert.NoError(err)
+
+	decoded, err := DecodeArguments(arguments, data)
+	tAssert.NoError(err)
+	tAssert.Equal([]Value{
+		{Name: "to", Type: "address", Value: to},
+		{Name: "amount", Type: "uint256", Value: big.NewInt(42)},
+	}, decoded)
+
+	_, err = DecodeArguments(arguments, data[:40])
+	tAssert.Error(err)
+}
+
+func TestDecoder_InputOutputLog(t *testing.T) {
+	tAssert := assert.New(t)
+
+	decoder, err := NewDecoder(context.TODO(), nil, valueTestAbi)
+	tAssert.NoError(err)
+
+	maker := common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE")
+	order := struct {
+		Maker   common.Address
+		Amounts []*big.Int
+		Salt    [4]byte
+	}{maker, []*big.Int{big.NewInt(1), big.NewInt(2)}, [4]byte{0xde, 0xad, 0xbe, 0xef}}
+
+	calldata, err := decoder.EncodeCall("swap", order)
+	tAssert.NoError(err)
+
+	input, err := decoder.DecodeInput(calldata)
+	tAssert.NoError(err)
+	tAssert.Equal("swap", input.Name)
+	tAssert.Len(input.Arguments, 1)
+
+	tuple := input.Arguments[0]
+	tAssert.Equal("order", tuple.Name)
+	tAssert.Nil(tuple.Value)
+	tAssert.Equal(Value{Name: "maker", Type: "address", Value: maker}, tuple.Components[0])
+	tAssert.Equal([]Value{
+		{Type: "uint128", Value: big.NewInt(1)},
+		{Type: "uint128", Value: big.NewInt(2)},
+	}, tuple.Components[1].Components)
+	tAssert.Equal(hexutil.Bytes{0xde, 0xad, 0xbe, 0xef}, tuple.Components[2].Value)
+
+	raw, err := json.Marshal(tuple.Components[1].Components[0])
+	tAssert.NoError(err)
+	tAssert.JSONEq(`{"type":"uint128","value":"1"}`, string(raw))
+
+	_, err = decoder.DecodeInput(common.FromHex("0x12345678"))
+	tAssert.ErrorIs(err, ErrMethodNotFound)
+
+	outputs, err := decoder.DecodeOutput("transfer", common.LeftPadBytes([]byte{1}, 32))
+	tAssert.NoError(err)
+	tAssert.Equal([]Value{{Type: "bool", Value: true}}, outputs)
+
+	to := common.HexToAddress("0x005D5631EF919DcDa961f0DE1539d62E3f0eBf37")
+	event, err := decoder.DecodeLog(gethtypes.Log{
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(maker.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes([]byte{0x03, 0xe8}, 32),
+	})
+	tAssert.NoError(err)
+	tAssert.Equal("Transfer", event.Name)
+	tAssert.Equal(Value{Name: "to", Type: "address", Indexed: true, Value: to}, event.Arguments[1])
+	tAssert.Equal(big.NewInt(1000), event.Arguments[2].Value)
+
+	_, err = decoder.DecodeLog(gethtypes.Log{})
+	tAssert.ErrorIs(err, ErrAnonymousLog)
+}
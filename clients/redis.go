@@ -74,12 +74,69 @@ func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
 	return []byte(result), nil
 }
 
+// MGet retrieves the values of multiple keys from the Redis database in a single round trip.
+// Values are returned in the order of the keys, missing keys are returned as nil.
+func (r *Redis) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	results, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make([][]byte, len(results))
+	for i, result := range results {
+		if value, ok := result.(string); ok {
+			toReturn[i] = []byte(value)
+		}
+	}
+	return toReturn, nil
+}
+
 // Write sets the value of a key in the Redis database with an optional expiration duration.
 // It returns an error if any occurred during the write operation.
 func (r *Redis) Write(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
+// maxUpdateAttempts is the number of times Update retries the transaction aborted by concurrent writers.
+const maxUpdateAttempts = 10
+
+// Update atomically replaces the value of the key with the one returned by fn, which receives the current
+// value or nil when the key is missing. The key is watched (WATCH/MULTI/EXEC) and the transaction is retried
+// when the key is modified by another client in the meantime. Returning nil value from fn leaves the key untouched.
+func (r *Redis) Update(ctx context.Context, key string, fn func(current []byte) ([]byte, error)) error {
+	update := func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, key).Bytes()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+
+		value, err := fn(current)
+		if err != nil || value == nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, value, 0)
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		err = r.client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+
+	return err
+}
+
 // Exists checks if a key exists in the Redis database.
 // It returns a boolean indicating the existence of the key and an error if any occurred during the check.
 func (r *Redis) Exists(ctx context.Context, key string) (bool, error) {
@@ -0,0 +1,214 @@
+/*
+Copyright © 2023 TxPull <code@txpull.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package syncers_cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/txpull/unpack/clients"
+	etherscan_crawler "github.com/txpull/unpack/crawlers/etherscan"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/db/models"
+	"github.com/txpull/unpack/options"
+	"github.com/txpull/unpack/scanners"
+	"github.com/txpull/unpack/types"
+	"github.com/txpull/unpack/verifier"
+	"go.uber.org/zap"
+)
+
+var etherscanCmd = &cobra.Command{
+	Use:     "etherscan",
+	Aliases: []string{"bscscan"},
+	Short:   "Process verified contracts from etherscan-compatible explorers (etherscan, bscscan, polygonscan...)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		network, err := cmd.Flags().GetString("network")
+		if err != nil {
+			return err
+		}
+
+		client, err := clients.NewEthClient(cmd.Context(), options.G().GetNode(network, "archive"))
+		if err != nil {
+			return fmt.Errorf("failure to initialize eth client: %s", err)
+		}
+
+		chainId, err := client.GetNetworkID(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failure to get network id: %s", err)
+		}
+
+		scanner, err := newEtherscanProviders().Get(chainId)
+		if err != nil {
+			return err
+		}
+
+		etherscanPath := viper.GetString("syncers.etherscan.verified_contracts_path")
+
+		if etherscanPath == "" {
+			currentDir, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			etherscanPath = path.Join(currentDir, "data")
+		}
+
+		// Every explorer has its own export of verified contracts, e.g. data/bscscan/verified-contracts.csv
+		etherscanVerifiedCsvPath := path.Join(etherscanPath, scanner.Explorer().Name, "verified-contracts.csv")
+
+		zap.L().Info(
+			"Starting to process explorer verified contracts...",
+			zap.String("explorer", scanner.Explorer().Name),
+			zap.String("chain_id", chainId.String()),
+			zap.String("etherscan-csv-path", etherscanVerifiedCsvPath),
+		)
+
+		store, closeStore, err := newStore(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer closeStore()
+
+		opts := []etherscan_crawler.Option{
+			etherscan_crawler.WithRequestLimit(8),
+			etherscan_crawler.WithDataPath(etherscanVerifiedCsvPath),
+			etherscan_crawler.WithMaxRetry(5),
+			etherscan_crawler.WithBackoffFactor(2),
+			etherscan_crawler.WithScanner(scanner),
+			etherscan_crawler.WithStore(store),
+			etherscan_crawler.WithEthClient(client),
+			etherscan_crawler.WithChainID(chainId),
+		}
+
+		// Sources are verified against the chain only when solc binaries are available, e.g. ~/.unpack/solc/solc-0.8.19
+		if solcPath := viper.GetString("syncers.etherscan.solc_path"); solcPath != "" {
+			opts = append(opts, etherscan_crawler.WithCompiler(verifier.NewSolc(solcPath)))
+		}
+
+		if viper.GetBool("syncers.etherscan.write_to_clickhouse") {
+			cdb, err := db.NewClickHouse(cmd.Context(), options.G().Database.Clickhouse)
+			if err != nil {
+				return err
+			}
+
+			if err := db.ExecClickHouseQuery(cmd.Context(), cdb, "SET allow_experimental_object_type = ?;", "1"); err != nil {
+				return fmt.Errorf("failure to set allow_experimental_object_type: %s", err)
+			}
+
+			if err := models.CreateContractsTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) contracts table: %s", err)
+			}
+
+			if err := models.CreateContractSourcesTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) contract_sources table: %s", err)
+			}
+
+			if err := models.CreateMethodsTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) methods table: %s", err)
+			}
+
+			if err := models.CreateMethodMapperTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) methods_mapper table: %s", err)
+			}
+
+			if err := models.CreateEventsTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) events table: %s", err)
+			}
+
+			if err := models.CreateEventMapperTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) events_mapper table: %s", err)
+			}
+
+			if err := models.CreateErrorsTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) errors table: %s", err)
+			}
+
+			if err := models.CreateProxiesTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) proxies table: %s", err)
+			}
+
+			opts = append(opts, etherscan_crawler.WithClickHouseDb(cdb))
+		}
+
+		crawler := etherscan_crawler.NewVerifiedContractsWritter(
+			cmd.Context(),
+			opts...,
+		)
+
+		contracts, err := crawler.GatherVerifiedContracts()
+		if err != nil {
+			return err
+		}
+
+		if err := crawler.ProcessVerifiedContracts(contracts); err != nil {
+			return err
+		}
+
+		zap.L().Info("Successfully processed verified contracts")
+
+		return nil
+	},
+}
+
+// newEtherscanProviders creates etherscan-compatible providers keyed by the chain ID. Explorers known by
+// default are always available, configured explorers override their URL, API key and rate limit.
+func newEtherscanProviders() *scanners.EtherscanProviders {
+	explorers := make(map[int64]scanners.EtherscanExplorer, len(scanners.EtherscanExplorers))
+	for chainId, explorer := range scanners.EtherscanExplorers {
+		explorers[chainId] = explorer
+	}
+
+	for name, client := range options.G().Clients.Etherscan {
+		explorer, ok := explorers[client.ChainID]
+		if !ok {
+			explorer = scanners.EtherscanExplorer{
+				Name:             name,
+				ChainID:          client.ChainID,
+				VerificationType: types.ContractVerificationTypeEtherscan,
+			}
+		}
+
+		if client.API.URL != "" {
+			explorer.URL = client.API.URL
+		}
+
+		if client.RateLimitS > 0 {
+			explorer.RateLimit = client.RateLimitS
+		}
+
+		explorer.APIKey = client.API.Key
+		explorers[client.ChainID] = explorer
+	}
+
+	toReturn := make([]scanners.EtherscanExplorer, 0, len(explorers))
+	for _, explorer := range explorers {
+		toReturn = append(toReturn, explorer)
+	}
+
+	return scanners.NewEtherscanProviders(toReturn)
+}
+
+func init() {
+	etherscanCmd.Flags().String("network", "binance", "Network (ethereum, binance, polygon) whose explorer verified contracts are processed")
+}
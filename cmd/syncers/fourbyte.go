@@ -28,7 +28,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"github.com/txpull/unpack/clients"
 	"github.com/txpull/unpack/crawlers/fourbyte"
 	"github.com/txpull/unpack/db"
 	"github.com/txpull/unpack/db/models"
@@ -41,10 +40,11 @@ var fourbyteCmd = &cobra.Command{
 	Use:   "fourbyte",
 	Short: "Download, process and store signatures from 4byte.directory",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		rdb, err := clients.NewRedis(cmd.Context(), options.G().Database.Redis)
+		store, closeStore, err := newStore(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failure to initialize redis client: %s", err)
+			return err
 		}
+		defer closeStore()
 
 		var fourbOpts []fourbyte.WriterOption
 
@@ -57,7 +57,7 @@ var fourbyteCmd = &cobra.Command{
 		opts := append(fourbOpts,
 			fourbyte.WithCtx(cmd.Context()),
 			fourbyte.WithProvider(provider),
-			fourbyte.WithRedis(rdb),
+			fourbyte.WithStore(store),
 			fourbyte.WithCooldown(100*time.Millisecond),
 			fourbyte.WithChainID(big.NewInt(viper.GetInt64("syncers.fourbyte.chain_id"))),
 		)
@@ -93,6 +93,10 @@ var fourbyteCmd = &cobra.Command{
 				return fmt.Errorf("failure to create (if does not exist) events_mapper table: %s", err)
 			}
 
+			if err := models.CreateErrorsTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) errors table: %s", err)
+			}
+
 			opts = append(opts, fourbyte.WithClickHouseDb(cdb))
 		}
 
@@ -102,6 +106,24 @@ var fourbyteCmd = &cobra.Command{
 			return err
 		}
 
+		// Event signatures are crawled with their own page cursor, after function signatures.
+		if viper.GetBool("syncers.fourbyte.crawl_events") {
+			eventsProvider := scanners.NewFourByteProvider(
+				scanners.WithCtx(cmd.Context()),
+				scanners.WithURL(viper.GetString("syncers.fourbyte.events_url")),
+				scanners.WithMaxRetries(3),
+			)
+
+			eventsCrawler := fourbyte.NewFourByteWriter(append(opts,
+				fourbyte.WithProvider(eventsProvider),
+				fourbyte.WithMode(fourbyte.ModeEvents),
+			)...)
+
+			if err := eventsCrawler.Crawl(); err != nil {
+				return err
+			}
+		}
+
 		zap.L().Info("Successfully processed 4byte.dictionary signatures")
 
 		return nil
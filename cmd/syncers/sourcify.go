@@ -42,10 +42,11 @@ var sourcifyCmd = &cobra.Command{
 	Use:   "sourcify",
 	Short: "Download, process and store contracts from sourcify.dev",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		rdb, err := clients.NewRedis(cmd.Context(), options.G().Database.Redis)
+		store, closeStore, err := newStore(cmd.Context())
 		if err != nil {
-			return fmt.Errorf("failure to initialize redis client: %s", err)
+			return err
 		}
+		defer closeStore()
 
 		var sourcifyOpts []sourcify.WriterOption
 
@@ -58,27 +59,24 @@ var sourcifyCmd = &cobra.Command{
 			sourcify_go.WithRateLimit(viper.GetInt("syncers.sourcify.rate_limit_s"), 1*time.Second),
 		)
 
-		bitquery := scanners.NewBitQueryProvider(
-			viper.GetString("bitquery.api.url"),
-			viper.GetString("bitquery.api.key"),
-		)
-
 		client, err := clients.NewEthClient(cmd.Context(), options.G().Networks.Ethereum.ArchiveNode)
 		if err != nil {
 			return err
 		}
 
-		bscscan := scanners.NewBscScanProvider(viper.GetString("bscscan.api.url"), viper.GetString("bscscan.api.key"))
-
 		opts := append(sourcifyOpts,
 			sourcify.WithCtx(cmd.Context()),
 			sourcify.WithSourcify(provider),
-			sourcify.WithRedis(rdb),
-			sourcify.WithBitQuery(bitquery),
+			sourcify.WithStore(store),
 			sourcify.WithEthClient(client),
-			sourcify.WithBscScan(bscscan),
+			sourcify.WithEtherscan(newEtherscanProviders()),
 		)
 
+		// BitQuery is optional, contract creations are located using the archive node when it is not configured.
+		if bitquery := options.G().Clients.Bitquery.API; bitquery.Key != "" {
+			opts = append(opts, sourcify.WithBitQuery(scanners.NewBitQueryProvider(bitquery.URL, bitquery.Key)))
+		}
+
 		// If ClickHouse is enabled, we are going to write signatures into it
 		if viper.GetBool("syncers.sourcify.write_to_clickhouse") {
 			cdb, err := db.NewClickHouse(cmd.Context(), options.G().Database.Clickhouse)
@@ -94,6 +92,10 @@ var sourcifyCmd = &cobra.Command{
 				return fmt.Errorf("failure to create (if does not exist) contracts table: %s", err)
 			}
 
+			if err := models.CreateContractSourcesTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) contract_sources table: %s", err)
+			}
+
 			if err := models.CreateMethodsTable(cmd.Context(), cdb); err != nil {
 				return fmt.Errorf("failure to create (if does not exist) methods table: %s", err)
 			}
@@ -110,6 +112,10 @@ var sourcifyCmd = &cobra.Command{
 				return fmt.Errorf("failure to create (if does not exist) events_mapper table: %s", err)
 			}
 
+			if err := models.CreateErrorsTable(cmd.Context(), cdb); err != nil {
+				return fmt.Errorf("failure to create (if does not exist) errors table: %s", err)
+			}
+
 			opts = append(opts, sourcify.WithClickHouseDb(cdb))
 		}
 
@@ -22,7 +22,13 @@ THE SOFTWARE.
 package syncers_cmd
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/spf13/cobra"
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/options"
 )
 
 // fixturesCmd represents the fixtures command
@@ -33,7 +39,27 @@ var syncerCmd = &cobra.Command{
 
 func Init(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(syncerCmd)
-	syncerCmd.AddCommand(bscscanCmd)
+	syncerCmd.AddCommand(etherscanCmd)
 	syncerCmd.AddCommand(fourbyteCmd)
 	syncerCmd.AddCommand(sourcifyCmd)
 }
+
+// newStore returns the store the syncers write into: BadgerDB when its path is configured, Redis otherwise.
+// The returned function closes the store once the syncer is done.
+func newStore(ctx context.Context) (db.Store, func() error, error) {
+	if path := options.G().Database.Badger.Path; path != "" {
+		bdb, err := db.NewBadgerDB(db.WithContext(ctx), db.WithDbPath(path))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failure to open badger database: %s", err)
+		}
+
+		return db.NewBadgerStore(bdb), bdb.Close, nil
+	}
+
+	rdb, err := clients.NewRedis(ctx, options.G().Database.Redis)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failure to initialize redis client: %s", err)
+	}
+
+	return rdb, func() error { return nil }, nil
+}
@@ -3,15 +3,25 @@
 package contracts
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/txpull/sourcify-go"
 	"github.com/txpull/unpack/abis"
 	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/creations"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/db/models"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/opcodes"
+	"github.com/txpull/unpack/proxies"
 	"github.com/txpull/unpack/readers"
 	"github.com/txpull/unpack/scanners"
 	"github.com/txpull/unpack/types"
@@ -29,13 +39,57 @@ type Decoder struct {
 	readerManager  *readers.Manager
 	bitquery       *scanners.BitQueryProvider
 	ethClient      *clients.EthClient
-	bscscan        *scanners.BscScanProvider
+	etherscan      *scanners.EtherscanProviders
+	proxyResolver  *proxies.Resolver
+	locator        *creations.Locator
+	clickhouseDb   *db.ClickHouse
+
+	// cacheSize is the number of records kept by each of the caches below.
+	cacheSize int
+
+	// creationsCache holds the located contract creations keyed by the chain and address. Nil creation
+	// marks the contract whose creation could not be found, so that the lookup is not retried either.
+	creationsCache *lru.Cache[string, *creations.Creation]
+
+	// partialsCache holds the runtime bytecode and partial ABI of the unverified contracts keyed by the
+	// chain and address, so that they are not rebuilt from the chain and signature database on every decode.
+	partialsCache *lru.Cache[string, *partialContract]
+
+	// proxiesCache holds the resolved proxy links keyed by the chain and address for the proxyCacheTTL.
+	// Nil proxy marks the contract that is not a proxy. Proxies can be upgraded, hence the entries expire.
+	proxiesCache  *lru.Cache[string, proxyEntry]
+	proxyCacheTTL time.Duration
+	// now returns the current time, replaced in tests.
+	now func() time.Time
 }
 
+// proxyEntry is the cached proxy resolution, nil proxy if the contract is not a proxy.
+type proxyEntry struct {
+	proxy     *types.Proxy
+	expiresAt time.Time
+}
+
+// partialContract is the cached part of the unverified contract response, shared by the responses.
+type partialContract struct {
+	bytecode  []byte
+	selectors []string
+	abi       *abis.Decoder
+}
+
+const (
+	// defaultCacheSize is the number of records kept by each of the Decoder caches by default.
+	defaultCacheSize = 10000
+
+	// defaultProxyCacheTTL is the time the resolved proxy links are kept by default.
+	defaultProxyCacheTTL = 10 * time.Minute
+)
+
 // Option defines a function type that applies configurations to a Decoder.
 // It is used to customize the context held by the Decoder.
 type Option func(*Decoder)
 
+// WithBitQuery sets the BitQuery provider used to speed up the contract creation lookups.
+// It is optional, creations are located using the eth client alone when it is not set.
 func WithBitQuery(bq *scanners.BitQueryProvider) Option {
 	return func(c *Decoder) {
 		c.bitquery = bq
@@ -54,9 +108,9 @@ func WithReaderManager(manager *readers.Manager) Option {
 	}
 }
 
-func WithBscScan(client *scanners.BscScanProvider) Option {
+func WithEtherscan(client *scanners.EtherscanProviders) Option {
 	return func(w *Decoder) {
-		w.bscscan = client
+		w.etherscan = client
 	}
 }
 
@@ -66,8 +120,31 @@ func WithSourcify(client *sourcify.Client) Option {
 	}
 }
 
+// WithClickHouseDb sets the clickhouse database the resolved proxy to implementation links are stored into.
+func WithClickHouseDb(client *db.ClickHouse) Option {
+	return func(w *Decoder) {
+		w.clickhouseDb = client
+	}
+}
+
+// WithCacheSize sets the number of records kept by each of the Decoder caches, located contract creations,
+// unverified contracts and proxy links.
+func WithCacheSize(size int) Option {
+	return func(w *Decoder) {
+		w.cacheSize = size
+	}
+}
+
+// WithProxyCacheTTL sets the time the resolved proxy links, and the contracts found not to be proxies, are kept.
+// Proxy upgrades are noticed once the link expires. Zero disables the cache and proxies are resolved on every decode.
+func WithProxyCacheTTL(ttl time.Duration) Option {
+	return func(w *Decoder) {
+		w.proxyCacheTTL = ttl
+	}
+}
+
 func NewDecoder(ctx context.Context, opts ...Option) (*Decoder, error) {
-	decoder := &Decoder{ctx: ctx}
+	decoder := &Decoder{ctx: ctx, cacheSize: defaultCacheSize, proxyCacheTTL: defaultProxyCacheTTL, now: time.Now}
 
 	// Apply all options to decoder
 	for _, opt := range opts {
@@ -83,46 +160,301 @@ func NewDecoder(ctx context.Context, opts ...Option) (*Decoder, error) {
 		return nil, errors.New("reader manager is required")
 	}
 
-	if decoder.bitquery == nil {
-		return nil, errors.New("bitquery client is required")
+	proxyResolver, err := proxies.NewResolver(ctx, proxies.WithEthClient(decoder.ethClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy resolver: %w", err)
 	}
+	decoder.proxyResolver = proxyResolver
+
+	locatorOpts := []creations.Option{creations.WithEthClient(decoder.ethClient)}
+	if decoder.bitquery != nil {
+		locatorOpts = append(locatorOpts, creations.WithBitQuery(decoder.bitquery))
+	}
+
+	locator, err := creations.NewLocator(ctx, locatorOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create creation locator: %w", err)
+	}
+	decoder.locator = locator
+
+	if decoder.cacheSize <= 0 {
+		return nil, errors.New("cache size must be positive")
+	}
+	decoder.creationsCache = lru.NewCache[string, *creations.Creation](decoder.cacheSize)
+	decoder.partialsCache = lru.NewCache[string, *partialContract](decoder.cacheSize)
+	decoder.proxiesCache = lru.NewCache[string, proxyEntry](decoder.cacheSize)
 
 	return decoder, nil
 }
 
+// DecodeByAddress builds the full contract response for the contract at the address, including its runtime
+// and creation bytecode with opcodes, creation details and constructor arguments. It costs several RPC calls,
+// use DecodeAbiByAddress when only calls to the contract are to be decoded. When the contract is a proxy,
+// the implementation contract is resolved as well and its ABI becomes the response ABI so that calls made
+// to the proxy are decoded against the implementation.
 func (c *Decoder) DecodeByAddress(chainId *big.Int, addr common.Address, abi *abis.Decoder) (*ContractResponse, error) {
-	for _, reader := range c.readerManager.GetSortedReaders() {
-		contract, err := reader.GetContractByAddress(chainId, addr)
-		if err != nil {
+	return c.decode(chainId, addr, true)
+}
+
+// DecodeAbiByAddress builds the contract response holding only the ABI, resolved through the proxy
+// implementation when needed. Bytecode, opcodes and creation details are left out, so that decoding
+// transactions, traces and reverts does not cost additional RPC calls for every verified contract.
+func (c *Decoder) DecodeAbiByAddress(chainId *big.Int, addr common.Address) (*ContractResponse, error) {
+	return c.decode(chainId, addr, false)
+}
+
+func (c *Decoder) decode(chainId *big.Int, addr common.Address, full bool) (*ContractResponse, error) {
+	response, err := c.decodeByAddress(chainId, addr, full)
+	if err != nil || response == nil {
+		return response, err
+	}
+
+	c.resolveProxy(chainId, response, full)
+
+	return response, nil
+}
+
+func (c *Decoder) decodeByAddress(chainId *big.Int, addr common.Address, full bool) (*ContractResponse, error) {
+	contract, err := c.readerManager.GetContractByAddress(c.ctx, chainId, addr)
+	if err == nil {
+		return c.buildContractResponse(chainId, contract, full)
+	}
+
+	// Missing contract is expected for unverified contracts, only reader failures are worth the error.
+	if !errors.Is(err, readers.ErrRecordNotFound) {
+		zap.L().Error(
+			"failed to get contract by address from the readers",
+			zap.String("address", addr.Hex()),
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Error(err),
+		)
+	}
+
+	zap.L().Info(
+		"Contract not found in any of the database readers, trying to fetch it from the blockchain",
+		zap.String("address", addr.Hex()),
+		zap.Int64("chain_id", chainId.Int64()),
+	)
+
+	return c.buildUnverifiedContractResponse(chainId, addr, full)
+}
+
+// resolveProxy checks whether the contract is a proxy and attaches the implementation contract to the response.
+// Failures are only logged as the proxy contract itself was already decoded.
+func (c *Decoder) resolveProxy(chainId *big.Int, response *ContractResponse, full bool) {
+	proxy, err := c.lookupProxy(chainId, response.Address)
+	if err != nil {
+		if !errors.Is(err, proxies.ErrNotProxy) {
 			zap.L().Error(
-				"failed to get contract by address by selected reader",
-				zap.String("address", addr.Hex()),
+				"failed to resolve proxy implementation",
+				zap.String("address", response.Address.Hex()),
 				zap.Int64("chain_id", chainId.Int64()),
-				zap.String("reader_name", reader.String()),
 				zap.Error(err),
 			)
-			continue
 		}
+		return
+	}
 
-		response, err := c.buildContractResponse(contract)
-		if err != nil {
-			return nil, err
+	response.Proxy = proxy
+
+	implementation, err := c.decodeByAddress(chainId, proxy.Implementation, full)
+	if err != nil || implementation == nil {
+		zap.L().Error(
+			"failed to decode proxy implementation contract",
+			zap.String("address", response.Address.Hex()),
+			zap.String("implementation", proxy.Implementation.Hex()),
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	response.Implementation = implementation
+	if implementation.Abi != nil {
+		response.ProxyAbi = response.Abi
+		response.Abi = implementation.Abi
+	}
+}
+
+// lookupProxy returns the proxy to implementation link of the contract, or proxies.ErrNotProxy. Links, together
+// with the contracts that are not proxies, are cached for the proxy cache TTL. Link stored in the clickhouse
+// database is preferred, storage slots are read from the chain only for contracts without one, and the resolved
+// link is stored for the following decodes.
+func (c *Decoder) lookupProxy(chainId *big.Int, addr common.Address) (*types.Proxy, error) {
+	key := chainId.String() + ":" + addr.Hex()
+	if entry, ok := c.proxiesCache.Get(key); ok && c.now().Before(entry.expiresAt) {
+		if entry.proxy == nil {
+			return nil, proxies.ErrNotProxy
 		}
+		return entry.proxy, nil
+	}
 
-		return response, nil
+	proxy, err := c.findProxy(chainId, addr)
+	if c.proxyCacheTTL > 0 && (err == nil || errors.Is(err, proxies.ErrNotProxy)) {
+		c.proxiesCache.Add(key, proxyEntry{proxy: proxy, expiresAt: c.now().Add(c.proxyCacheTTL)})
 	}
 
-	zap.L().Info(
-		"Contract not found in any of the database readers, trying to fetch it from the blockchain",
+	return proxy, err
+}
+
+// findProxy returns the stored proxy to implementation link of the contract, resolving it from the chain
+// and storing it when there is none.
+func (c *Decoder) findProxy(chainId *big.Int, addr common.Address) (*types.Proxy, error) {
+	if c.clickhouseDb != nil {
+		proxy, err := models.GetProxy(c.ctx, c.clickhouseDb, chainId, addr)
+		if err == nil {
+			return proxy, nil
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			zap.L().Error(
+				"failed to get stored proxy implementation link",
+				zap.String("address", addr.Hex()),
+				zap.Int64("chain_id", chainId.Int64()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	proxy, err := c.proxyResolver.Resolve(chainId, addr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.storeProxy(proxy)
+
+	return proxy, nil
+}
+
+// storeProxy writes the proxy to implementation link into the clickhouse database, if one is set.
+func (c *Decoder) storeProxy(proxy *types.Proxy) {
+	if c.clickhouseDb == nil {
+		return
+	}
+
+	exists, err := models.ProxyExists(c.ctx, c.clickhouseDb, proxy)
+	if err == nil && !exists {
+		err = models.InsertProxy(c.ctx, c.clickhouseDb, proxy)
+	}
+
+	if err != nil {
+		zap.L().Error(
+			"failed to store proxy implementation link",
+			zap.String("address", proxy.Address.Hex()),
+			zap.String("implementation", proxy.Implementation.Hex()),
+			zap.Error(err),
+		)
+	}
+}
+
+// buildUnverifiedContractResponse builds the best-effort response for the contract that is not verified.
+// Function selectors are extracted from the runtime bytecode dispatcher and resolved against the
+// signature database into the synthetic partial ABI. Nil response is returned for addresses without code.
+// Opcodes are disassembled only for the full response.
+func (c *Decoder) buildUnverifiedContractResponse(chainId *big.Int, addr common.Address, full bool) (*ContractResponse, error) {
+	partial, err := c.partialContract(chainId, addr)
+	if err != nil || partial == nil {
+		return nil, err
+	}
+
+	response := &ContractResponse{
+		Address:             addr,
+		IsPartial:           true,
+		Selectors:           partial.selectors,
+		RuntimeBytecode:     partial.bytecode,
+		RuntimeBytecodeSize: uint64(len(partial.bytecode)),
+		Abi:                 partial.abi,
+	}
+
+	if full {
+		response.RuntimeOpCodes = opcodes.Disassemble(partial.bytecode)
+	}
+
+	return response, nil
+}
+
+// partialContract returns the runtime bytecode, selectors and partial ABI of the unverified contract.
+// They are cached per address, addresses without code are not as the contract can still be deployed there.
+func (c *Decoder) partialContract(chainId *big.Int, addr common.Address) (*partialContract, error) {
+	key := chainId.String() + ":" + addr.Hex()
+	if partial, ok := c.partialsCache.Get(key); ok {
+		return partial, nil
+	}
+
+	bytecode, err := helpers.GetBytecode(c.ctx, c.ethClient, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runtime bytecode: %w", err)
+	}
+
+	if len(bytecode) == 0 {
+		return nil, nil
+	}
+
+	selectors := make([]string, 0)
+	for _, selector := range opcodes.ExtractSelectors(bytecode) {
+		selectors = append(selectors, common.Bytes2Hex(selector[:]))
+	}
+
+	methods := c.resolveMethodsBySelectors(chainId, selectors)
+
+	abiDecoder, err := abis.NewPartialDecoder(c.ctx, c.readerManager, methods)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partial abi decoder: %s", err)
+	}
+
+	zap.L().Debug(
+		"Built partial contract ABI from bytecode selectors",
 		zap.String("address", addr.Hex()),
 		zap.Int64("chain_id", chainId.Int64()),
+		zap.Int("selectors", len(selectors)),
+		zap.Int("resolved_methods", len(methods)),
 	)
 
-	return nil, nil
+	partial := &partialContract{bytecode: bytecode, selectors: selectors, abi: abiDecoder}
+	c.partialsCache.Add(key, partial)
+
+	return partial, nil
 }
 
-// TODO: Add receipt information including log parsing
-func (c *Decoder) buildContractResponse(contract *types.Contract) (*ContractResponse, error) {
+// resolveMethodsBySelectors looks up the methods matching the selectors in the signature database with
+// a single batch lookup. Methods whose arguments can not be represented in the synthetic ABI are skipped.
+// Methods are returned in the order of the selectors.
+func (c *Decoder) resolveMethodsBySelectors(chainId *big.Int, selectors []string) []*types.Method {
+	found, err := c.readerManager.GetMethodsBySignatures(c.ctx, chainId, selectors)
+	if err != nil {
+		zap.L().Debug(
+			"failed to get methods by selectors",
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Int("selectors", len(selectors)),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	methods := make([]*types.Method, 0, len(found))
+	for _, selector := range selectors {
+		method, ok := found[selector]
+		if !ok || method == nil {
+			continue
+		}
+
+		if _, err := method.GetABIArguments(); err != nil {
+			continue
+		}
+
+		if _, err := method.GetABIReturns(); err != nil {
+			continue
+		}
+
+		methods = append(methods, method)
+	}
+
+	return methods
+}
+
+// buildContractResponse builds the response for the verified contract. Bytecode, creation details and
+// constructor arguments are populated only for the full response.
+func (c *Decoder) buildContractResponse(chainId *big.Int, contract *types.Contract, full bool) (*ContractResponse, error) {
 	if contract == nil {
 		return nil, errors.New("contract is nil")
 	}
@@ -132,12 +464,172 @@ func (c *Decoder) buildContractResponse(contract *types.Contract) (*ContractResp
 		return nil, fmt.Errorf("failed to create abi decoder: %s", err)
 	}
 
-	return &ContractResponse{
+	response := &ContractResponse{
 		BlockHash:       contract.BlockHash,
 		TransactionHash: contract.TransactionHash,
-		// TODO: Add receipt information including log parsing,
 		Address:         contract.Address,
-		RuntimeBytecode: nil,
 		Abi:             abiDecoder,
-	}, nil
+	}
+
+	if !full {
+		return response, nil
+	}
+
+	contract = c.locateCreation(chainId, contract)
+	response.BlockHash = contract.BlockHash
+	response.TransactionHash = contract.TransactionHash
+
+	if err := c.populateBytecode(contract, response); err != nil {
+		return nil, err
+	}
+
+	c.populateConstructorArguments(contract, response)
+
+	// Creation transaction is not known for every contract (e.g. the node is not an archive node).
+	if contract.TransactionHash != (common.Hash{}) {
+		receipt, err := helpers.GetReceiptByHash(c.ctx, c.ethClient, contract.TransactionHash)
+		if err != nil {
+			zap.L().Error(
+				"failed to get contract creation receipt",
+				zap.String("address", contract.Address.Hex()),
+				zap.String("tx_hash", contract.TransactionHash.Hex()),
+				zap.Error(err),
+			)
+		} else {
+			response.ReceiptStatus = receipt.Status
+		}
+	}
+
+	return response, nil
+}
+
+// locateCreation returns the copy of the contract with the creation transaction, block and input filled in,
+// for the contract stored without them. Contract returned by the readers may be shared (e.g. by the caching
+// reader) and is never modified in place. Failures are only logged as the contract can still be decoded
+// without its creation details.
+func (c *Decoder) locateCreation(chainId *big.Int, contract *types.Contract) *types.Contract {
+	if contract.TransactionHash != (common.Hash{}) {
+		return contract
+	}
+
+	creation, err := c.locate(chainId, contract.Address)
+	if err != nil {
+		zap.L().Debug(
+			"failed to locate contract creation",
+			zap.String("address", contract.Address.Hex()),
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Error(err),
+		)
+		return contract
+	}
+
+	located := *contract
+	located.TransactionHash = creation.TransactionHash
+	located.BlockHash = creation.BlockHash
+
+	if len(located.CreationInput) == 0 {
+		located.CreationInput = creation.Bytecode
+	}
+
+	return &located
+}
+
+// locate returns the creation of the contract at the address. Creations are cached, together with
+// the contracts whose creation is not found, as every lookup binary searches eth_getCode over blocks.
+// Other failures (e.g. node errors) are not cached and the lookup is retried on the next decode.
+func (c *Decoder) locate(chainId *big.Int, addr common.Address) (*creations.Creation, error) {
+	key := chainId.String() + ":" + addr.Hex()
+
+	if creation, ok := c.creationsCache.Get(key); ok {
+		if creation == nil {
+			return nil, creations.ErrCreationNotFound
+		}
+		return creation, nil
+	}
+
+	creation, err := c.locator.Locate(chainId, addr)
+	if err == nil || errors.Is(err, creations.ErrCreationNotFound) || errors.Is(err, creations.ErrNoCode) {
+		c.creationsCache.Add(key, creation)
+	}
+
+	return creation, err
+}
+
+// populateBytecode fills in runtime and creation bytecode of the contract together with their opcodes.
+// Runtime bytecode is fetched from the chain unless it was already stored with the contract.
+// Creation bytecode is taken from the creation transaction input, which is only possible for
+// contracts deployed directly by the EOA. Factory deployments get it from the creation locator.
+func (c *Decoder) populateBytecode(contract *types.Contract, response *ContractResponse) error {
+	runtimeBytecode := contract.RuntimeBytecode
+	if len(runtimeBytecode) == 0 {
+		bytecode, err := helpers.GetBytecode(c.ctx, c.ethClient, contract.Address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get runtime bytecode: %w", err)
+		}
+		runtimeBytecode = bytecode
+	}
+
+	response.RuntimeBytecode = runtimeBytecode
+	response.RuntimeBytecodeSize = uint64(len(runtimeBytecode))
+	response.RuntimeOpCodes = opcodes.Disassemble(runtimeBytecode)
+
+	creationBytecode := contract.CreationInput
+	if len(creationBytecode) == 0 && contract.TransactionHash != (common.Hash{}) {
+		tx, _, err := helpers.GetTransactionByHash(c.ctx, c.ethClient, contract.TransactionHash)
+		if err != nil {
+			zap.L().Error(
+				"failed to get contract creation transaction",
+				zap.String("address", contract.Address.Hex()),
+				zap.String("tx_hash", contract.TransactionHash.Hex()),
+				zap.Error(err),
+			)
+		} else if tx.To() == nil {
+			creationBytecode = tx.Data()
+		}
+	}
+
+	response.ContractBytecode = creationBytecode
+	response.ContractBytecodeSize = uint64(len(creationBytecode))
+	response.ContractOpCodes = opcodes.Disassemble(creationBytecode)
+
+	return nil
+}
+
+// populateConstructorArguments decodes the arguments the contract was deployed with. Arguments reported by
+// the explorer are used when available, otherwise they are extracted from the creation bytecode.
+// Failures are only logged as plenty of contracts have no constructor arguments at all.
+func (c *Decoder) populateConstructorArguments(contract *types.Contract, response *ContractResponse) {
+	inputs := response.Abi.GetABI().Constructor.Inputs
+	if len(inputs) == 0 && len(contract.ConstructorABI) > 0 {
+		if constructor, err := contract.UnmarshalConstructorABI(); err == nil {
+			inputs = constructor.Inputs
+		}
+	}
+
+	if len(inputs) == 0 {
+		return
+	}
+
+	data, err := contract.GetConstructorArguments()
+	if err == nil && len(data) == 0 {
+		// Contracts stored before the creation input had its own field carry the input as their bytecode,
+		// which tells nothing about where the arguments start.
+		compiled := contract.Bytecode
+		if bytes.Equal(compiled, response.ContractBytecode) {
+			compiled = nil
+		}
+		data, err = abis.ExtractConstructorArguments(response.ContractBytecode, compiled, response.RuntimeBytecode)
+	}
+
+	if err == nil {
+		response.ConstructorArguments, err = abis.DecodeConstructorArguments(inputs, data)
+	}
+
+	if err != nil {
+		zap.L().Debug(
+			"failed to decode constructor arguments",
+			zap.String("address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+	}
 }
@@ -0,0 +1,265 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/creations"
+	"github.com/txpull/unpack/opcodes"
+	"github.com/txpull/unpack/proxies"
+	"github.com/txpull/unpack/readers"
+	"github.com/txpull/unpack/types"
+)
+
+const storageAbi = `[{"type":"function","name":"retrieve","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}]`
+
+func TestDecoder_PopulateBytecode(t *testing.T) {
+	tAssert := assert.New(t)
+
+	decoder := &Decoder{ctx: context.TODO()}
+
+	// PUSH1 0x80 PUSH1 0x40 MSTORE followed by the CALLVALUE guard.
+	runtime := common.FromHex("0x608060405234")
+	// Init code prefix: PUSH1 0x80 PUSH1 0x40 MSTORE CALLVALUE DUP1 ISZERO
+	creation := common.FromHex("0x6080604052348015")
+
+	contract := &types.Contract{
+		Address:         common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56"),
+		RuntimeBytecode: runtime,
+		CreationInput:   creation,
+	}
+
+	response := &ContractResponse{}
+	tAssert.NoError(decoder.populateBytecode(contract, response))
+
+	tAssert.Equal(runtime, response.RuntimeBytecode)
+	tAssert.Equal(uint64(len(runtime)), response.RuntimeBytecodeSize)
+	tAssert.Len(response.RuntimeOpCodes, 4)
+	tAssert.Equal(opcodes.Instruction{Offset: 0, OpCode: opcodes.PUSH1, Operand: []byte{0x80}, Gas: 3}, response.RuntimeOpCodes[0])
+	tAssert.Equal(opcodes.CALLVALUE, response.RuntimeOpCodes[3].OpCode)
+	tAssert.Equal(uint64(5), response.RuntimeOpCodes[3].Offset)
+
+	tAssert.Equal(creation, response.ContractBytecode)
+	tAssert.Equal(uint64(len(creation)), response.ContractBytecodeSize)
+	tAssert.Len(response.ContractOpCodes, 6)
+	tAssert.Equal(opcodes.ISZERO, response.ContractOpCodes[5].OpCode)
+}
+
+func TestDecoder_BuildContractResponseWithoutBytecode(t *testing.T) {
+	tAssert := assert.New(t)
+
+	decoder := &Decoder{ctx: context.TODO()}
+
+	contract := &types.Contract{
+		Address:         common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56"),
+		ABI:             storageAbi,
+		RuntimeBytecode: common.FromHex("0x608060405234"),
+	}
+
+	// ABI only response is built without touching the chain, eth client is not even set.
+	response, err := decoder.buildContractResponse(big.NewInt(56), contract, false)
+	tAssert.NoError(err)
+	tAssert.NotNil(response.Abi)
+	tAssert.Equal(contract.Address, response.Address)
+	tAssert.Empty(response.RuntimeBytecode)
+	tAssert.Empty(response.RuntimeOpCodes)
+	tAssert.Empty(response.ContractOpCodes)
+}
+
+func TestDecoder_LocateCreation(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+	addr := common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56")
+	unknown := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+
+	decoder := &Decoder{ctx: context.TODO(), creationsCache: lru.NewCache[string, *creations.Creation](10)}
+
+	// Cached lookups do not reach the locator, which is not even set.
+	creation := &creations.Creation{
+		Address:         addr,
+		BlockHash:       common.HexToHash("0x01"),
+		TransactionHash: common.HexToHash("0x02"),
+		Bytecode:        common.FromHex("0x6080604052"),
+	}
+	decoder.creationsCache.Add(chainId.String()+":"+addr.Hex(), creation)
+	decoder.creationsCache.Add(chainId.String()+":"+unknown.Hex(), nil)
+
+	contract := &types.Contract{Address: addr}
+
+	located := decoder.locateCreation(chainId, contract)
+	tAssert.NotSame(contract, located)
+	tAssert.Equal(creation.TransactionHash, located.TransactionHash)
+	tAssert.Equal(creation.BlockHash, located.BlockHash)
+	tAssert.Equal(creation.Bytecode, located.CreationInput)
+	tAssert.Empty(located.Bytecode)
+
+	// Contract returned by the readers is shared and stays untouched.
+	tAssert.Equal(common.Hash{}, contract.TransactionHash)
+	tAssert.Empty(contract.CreationInput)
+
+	// Contract whose creation is not found is returned as is.
+	notFound := &types.Contract{Address: unknown}
+	tAssert.Same(notFound, decoder.locateCreation(chainId, notFound))
+
+	_, err := decoder.locate(chainId, unknown)
+	tAssert.ErrorIs(err, creations.ErrCreationNotFound)
+
+	// Contract stored with its creation is not located at all.
+	stored := &types.Contract{Address: unknown, TransactionHash: common.HexToHash("0x03")}
+	tAssert.Same(stored, decoder.locateCreation(chainId, stored))
+}
+
+func TestDecoder_LocatedConstructorArguments(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+	addr := common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56")
+
+	// a1 65 "bzzr0" 58 20 <32 bytes> 0029
+	metadata := common.FromHex("0xa165627a7a72305820" + strings.Repeat("ab", 32) + "0029")
+	runtime := append(common.FromHex("0x6080604052600080fd"), metadata...)
+	compiled := append(common.FromHex("0x6080604052348015600f57600080fd5b50603f80601d6000396000f3fe"), runtime...)
+	arguments := common.FromHex(
+		"000000000000000000000000bebebebebebebebebebebebebebebebebebebebe" +
+			"00000000000000000000000000000000000000000000000000000000000001f4")
+
+	decoder := &Decoder{ctx: context.TODO(), creationsCache: lru.NewCache[string, *creations.Creation](10)}
+	decoder.creationsCache.Add(chainId.String()+":"+addr.Hex(), &creations.Creation{
+		Address:         addr,
+		TransactionHash: common.HexToHash("0x02"),
+		Bytecode:        append(append([]byte{}, compiled...), arguments...),
+	})
+
+	abiDecoder, err := abis.NewDecoder(context.TODO(), nil, `[{"type":"constructor","inputs":[{"name":"token","type":"address"},{"name":"fee","type":"uint256"}]}]`)
+	tAssert.NoError(err)
+
+	cases := []struct {
+		name     string
+		bytecode []byte
+	}{
+		{name: "compiled bytecode", bytecode: compiled},
+		// Arguments are found through the runtime metadata trailer instead.
+		{name: "no compiled bytecode"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tAssert := assert.New(t)
+
+			contract := decoder.locateCreation(chainId, &types.Contract{Address: addr, RuntimeBytecode: runtime, Bytecode: tc.bytecode})
+
+			response := &ContractResponse{Abi: abiDecoder}
+			tAssert.NoError(decoder.populateBytecode(contract, response))
+			tAssert.Equal(contract.CreationInput, response.ContractBytecode)
+
+			decoder.populateConstructorArguments(contract, response)
+			tAssert.Len(response.ConstructorArguments, 2)
+			tAssert.Equal(common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe"), response.ConstructorArguments[0].Value)
+			tAssert.Equal(big.NewInt(500), response.ConstructorArguments[1].Value)
+		})
+	}
+}
+
+// selectorsReader serves the methods by selector and counts the batch lookups.
+type selectorsReader struct {
+	readers.MockReader
+	methods map[string]*types.Method
+	calls   int
+}
+
+func (r *selectorsReader) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	r.calls++
+
+	toReturn := make(map[string]*types.Method)
+	for _, signature := range signatures {
+		if method, ok := r.methods[signature]; ok {
+			toReturn[signature] = method
+		}
+	}
+	return toReturn, nil
+}
+
+func TestDecoder_BuildUnverifiedContractResponse(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+	addr := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+
+	transfer, err := types.NewFourByteMethod("0xa9059cbb", "transfer(address,uint256)")
+	tAssert.NoError(err)
+	approve, err := types.NewFourByteMethod("0x095ea7b3", "approve(address,uint256)")
+	tAssert.NoError(err)
+
+	reader := &selectorsReader{methods: map[string]*types.Method{"a9059cbb": transfer, "095ea7b3": approve}}
+	manager, err := readers.NewManager(ctx, readers.WithReader("selectors", reader))
+	tAssert.NoError(err)
+
+	decoder := &Decoder{ctx: ctx, readerManager: manager, partialsCache: lru.NewCache[string, *partialContract](10)}
+
+	// Every selector is resolved with the single batch lookup, unknown ones are skipped.
+	methods := decoder.resolveMethodsBySelectors(chainId, []string{"095ea7b3", "deadbeef", "a9059cbb"})
+	tAssert.Equal([]*types.Method{approve, transfer}, methods)
+	tAssert.Equal(1, reader.calls)
+
+	abiDecoder, err := abis.NewPartialDecoder(ctx, manager, methods)
+	tAssert.NoError(err)
+
+	// Cached partial contract is served without reaching the chain, eth client is not even set.
+	runtime := common.FromHex("0x608060405234")
+	decoder.partialsCache.Add(chainId.String()+":"+addr.Hex(), &partialContract{
+		bytecode:  runtime,
+		selectors: []string{"095ea7b3", "a9059cbb"},
+		abi:       abiDecoder,
+	})
+
+	response, err := decoder.buildUnverifiedContractResponse(chainId, addr, false)
+	tAssert.NoError(err)
+	tAssert.True(response.IsPartial)
+	tAssert.Same(abiDecoder, response.Abi)
+	tAssert.Equal([]string{"095ea7b3", "a9059cbb"}, response.Selectors)
+	tAssert.Empty(response.RuntimeOpCodes)
+
+	// Responses are built anew, only the cached parts are shared.
+	full, err := decoder.buildUnverifiedContractResponse(chainId, addr, true)
+	tAssert.NoError(err)
+	tAssert.NotSame(response, full)
+	tAssert.Len(full.RuntimeOpCodes, 4)
+	tAssert.Equal(1, reader.calls)
+}
+
+func TestDecoder_LookupProxyCached(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+	proxyAddr := common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56")
+	notProxy := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+
+	now := time.Now()
+	decoder := &Decoder{
+		ctx:           context.TODO(),
+		proxiesCache:  lru.NewCache[string, proxyEntry](10),
+		proxyCacheTTL: time.Minute,
+		now:           func() time.Time { return now },
+	}
+
+	proxy := &types.Proxy{ChainID: chainId, Address: proxyAddr, Implementation: common.HexToAddress("0x01"), Type: types.ProxyTypeEIP1967}
+	decoder.proxiesCache.Add(chainId.String()+":"+proxyAddr.Hex(), proxyEntry{proxy: proxy, expiresAt: now.Add(time.Minute)})
+	decoder.proxiesCache.Add(chainId.String()+":"+notProxy.Hex(), proxyEntry{expiresAt: now.Add(time.Minute)})
+
+	// Cached resolutions do not reach the chain, proxy resolver is not even set.
+	resolved, err := decoder.lookupProxy(chainId, proxyAddr)
+	tAssert.NoError(err)
+	tAssert.Same(proxy, resolved)
+
+	_, err = decoder.lookupProxy(chainId, notProxy)
+	tAssert.ErrorIs(err, proxies.ErrNotProxy)
+}
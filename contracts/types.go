@@ -4,6 +4,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/txpull/unpack/abis"
 	"github.com/txpull/unpack/opcodes"
+	"github.com/txpull/unpack/types"
 )
 
 type ContractResponse struct {
@@ -25,21 +26,50 @@ type ContractResponse struct {
 	// RuntimeBytecodeSize represents the size of the runtime bytecode.
 	RuntimeBytecodeSize uint64 `json:"runtime_bytecode_size"`
 
-	// RuntimeOpCodes represents the decompiled version of the runtime bytecode.
-	RuntimeOpCodes []opcodes.OpCode `json:"runtime_opcodes"`
+	// RuntimeOpCodes represents the disassembled version of the runtime bytecode.
+	RuntimeOpCodes []opcodes.Instruction `json:"runtime_opcodes"`
 
-	// ContractBytecode represents the bytecode of the contract.
+	// ContractBytecode represents the creation (init) bytecode of the contract including constructor arguments.
 	ContractBytecode []byte `json:"contract_bytecode"`
 
 	// ContractBytecodeSize represents the size of the contract bytecode.
 	ContractBytecodeSize uint64 `json:"contract_bytecode_size"`
 
-	// ContractOpCodes represents the decompiled version of the contract bytecode.
-	ContractOpCodes []opcodes.OpCode `json:"contract_opcodes"`
+	// ContractOpCodes represents the disassembled version of the contract bytecode.
+	ContractOpCodes []opcodes.Instruction `json:"contract_opcodes"`
 
 	// Abi represents the decoded version of the contract bytecode.
 	Abi *abis.Decoder `json:"contract_abi"`
 
+	// IsPartial is set when the contract is not verified and Abi is built out of the selectors
+	// extracted from the runtime bytecode resolved against the signature database.
+	IsPartial bool `json:"is_partial"`
+
+	// Selectors represents hex encoded function selectors extracted from the runtime bytecode of the unverified contract.
+	Selectors []string `json:"selectors,omitempty"`
+
+	// Proxy represents the link to the implementation contract. It is nil if the contract is not a proxy.
+	Proxy *types.Proxy `json:"proxy,omitempty"`
+
+	// Implementation represents the implementation contract the proxy delegates calls to.
+	Implementation *ContractResponse `json:"implementation,omitempty"`
+
+	// ProxyAbi represents the ABI of the proxy contract itself. Abi is replaced with the implementation ABI for proxies.
+	ProxyAbi *abis.Decoder `json:"proxy_abi,omitempty"`
+
+	// ConstructorArguments represents decoded arguments the contract was deployed with.
+	ConstructorArguments []abis.Value `json:"constructor_arguments,omitempty"`
+
 	// ContractSourceCode represents the source code of the contract.
 	ContractSourceCode string `json:"contract_source_code"`
 }
+
+// HasPartialAbi reports whether Abi is the partial ABI of the unverified contract, which is the case for
+// the unverified proxy implementation as well. Partial ABI holds a single arbitrary method per selector.
+func (c *ContractResponse) HasPartialAbi() bool {
+	if c.Implementation != nil && c.Implementation.Abi != nil && c.Abi == c.Implementation.Abi {
+		return c.Implementation.IsPartial
+	}
+
+	return c.IsPartial
+}
@@ -1,4 +1,4 @@
-package bscscan
+package etherscan
 
 import "errors"
 
@@ -9,8 +9,8 @@ var (
 	// ErrMaxRateLimitReached is returned when the maximum rate limit is reached and retry is needed.
 	ErrMaxRateLimitReached = errors.New("max rate limit reached, retrying...")
 
-	// ErrFailedGetContractInfo is returned when failed to get contract information from BSCScan.
-	ErrFailedGetContractInfo = errors.New("failed to get contract information from BSCScan")
+	// ErrFailedGetContractInfo is returned when failed to get contract information from the explorer.
+	ErrFailedGetContractInfo = errors.New("failed to get contract information from the explorer")
 
 	// ErrFailedMarshalContractInfo is returned when failed to marshal contract information to binary.
 	ErrFailedMarshalContractInfo = errors.New("failed to marshal contract information to binary")
@@ -83,4 +83,43 @@ var (
 
 	// ErrFailedToCheckIfMethodCacheKeyExists is returned when failed to check if method cache key exists.
 	ErrFailedToCheckIfMethodCacheKeyExists = errors.New("failed to check if method cache key exists")
+
+	// ErrFailedProcessAbiErrors is returned when failed to process ABI custom errors.
+	ErrFailedProcessAbiErrors = errors.New("failed to process ABI errors")
+
+	// ErrFailedCheckErrorExistenceInRedis is returned when failed to check existence of custom error information in redis.
+	ErrFailedCheckErrorExistenceInRedis = errors.New("failed to check existence of error information in redis")
+
+	// ErrFailedToCheckIfErrorExists is returned when failed to check if custom error exists.
+	ErrFailedToCheckIfErrorExists = errors.New("failed to check if error exists")
+
+	// ErrFailedToInsertError is returned when failed to insert custom error.
+	ErrFailedToInsertError = errors.New("failed to insert error")
+
+	// ErrFailedToMarshalError is returned when failed to marshal custom error information to binary.
+	ErrFailedToMarshalError = errors.New("failed to marshal error information to binary")
+
+	// ErrFailedToWriteErrorToRedis is returned when failed to write custom error information to redis.
+	ErrFailedToWriteErrorToRedis = errors.New("failed to write error information to redis")
+
+	// ErrFailedToCheckIfProxyExists is returned when failed to check if proxy to implementation link exists.
+	ErrFailedToCheckIfProxyExists = errors.New("failed to check if proxy implementation link exists")
+
+	// ErrFailedToInsertProxy is returned when failed to insert proxy to implementation link.
+	ErrFailedToInsertProxy = errors.New("failed to insert proxy implementation link")
+
+	// ErrFailedParseSourceCode is returned when failed to parse the source code returned by the explorer.
+	ErrFailedParseSourceCode = errors.New("failed to parse source code")
+
+	// ErrFailedToInsertContractSource is returned when failed to insert the contract source tree.
+	ErrFailedToInsertContractSource = errors.New("failed to insert contract source")
+
+	// ErrFailedVerifyContract is returned when failed to verify the contract bytecode against the chain.
+	ErrFailedVerifyContract = errors.New("failed to verify contract bytecode")
+
+	// ErrFailedCompileContract is returned when failed to compile the verified sources of the contract.
+	ErrFailedCompileContract = errors.New("failed to compile contract sources")
+
+	// ErrFailedToAppendMethodCandidate is returned when failed to append the method to the selector candidates.
+	ErrFailedToAppendMethodCandidate = errors.New("failed to append method candidate")
 )
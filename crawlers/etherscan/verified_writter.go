@@ -1,42 +1,43 @@
-// Package bscscan provides utilities to interact with BSCScan for contract
+// Package etherscan provides utilities to interact with Etherscan-compatible
+// explorers (Etherscan, BscScan, Polygonscan, Arbiscan...) for contract
 // information and to persist that data in Redis.
 //
 // It is capable of reading contract data from CSV files, extracting
-// contract details from BSCScan, and writing them to Redis.
+// contract details from the explorer, and writing them to Redis.
 //
 // Usage:
 //
 //	ctx := context.Background()
 //	db := db.NewRedis("path/to/db")
 //
-//	writer := bscscan.New(
+//	writer := etherscan.NewVerifiedContractsWritter(
 //	    ctx,
-//	    bscscan.WithDataPath("path/to/csv"),
-//	    bscscan.WithScanner(&scanners.BscScanProvider{}),
-//	    bscscan.WithRequestLimit(6),
-//	    bscscan.WithRequestInterval(100*time.Millisecond),
-//	    bscscan.WithMaxRetry(5),
-//	    bscscan.WithBackoffFactor(2),
+//	    etherscan.WithDataPath("path/to/csv"),
+//	    etherscan.WithScanner(scanners.NewEtherscanProvider(scanners.EtherscanExplorers[56])),
+//	    etherscan.WithRequestLimit(6),
+//	    etherscan.WithRequestInterval(100*time.Millisecond),
+//	    etherscan.WithMaxRetry(5),
+//	    etherscan.WithBackoffFactor(2),
+//	    etherscan.WithRedis(db),
 //	)
 //
-//	writer.WithRedis(db)
-//
 //	contracts, err := writer.GatherVerifiedContracts()
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //
-//	if err := writer.ProcessVerifiedContracts(ctx, contracts); err != nil {
+//	if err := writer.ProcessVerifiedContracts(contracts); err != nil {
 //	    log.Fatal(err)
 //	}
 //
-// This will read verified contract details from BSCScan and persist the
+// This will read verified contract details from the explorer and persist the
 // data in the Redis for future use.
-package bscscan
+package etherscan
 
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"io"
 	"math"
 	"math/big"
@@ -54,6 +55,7 @@ import (
 	"github.com/txpull/unpack/helpers"
 	"github.com/txpull/unpack/scanners"
 	"github.com/txpull/unpack/types"
+	"github.com/txpull/unpack/verifier"
 	"go.uber.org/zap"
 )
 
@@ -64,8 +66,8 @@ const (
 	defaultBackoffFactor   = 2                      // Default backoff factor for exponential backoff
 )
 
-// Option represents a functional option for configuring the BscscanWriter.
-type Option func(*BscscanWriter)
+// Option represents a functional option for configuring the EtherscanWriter.
+type Option func(*EtherscanWriter)
 
 // CsvContract holds a transaction hash, a contract address, and a contract name.
 type CsvContract struct {
@@ -74,11 +76,11 @@ type CsvContract struct {
 	ContractName    string
 }
 
-// BscscanWriter provides methods to gather verified contracts, process them,
+// EtherscanWriter provides methods to gather verified contracts, process them,
 // and store their information in Redis.
-type BscscanWriter struct {
+type EtherscanWriter struct {
 	ctx             context.Context
-	scanner         *scanners.BscScanProvider
+	scanner         *scanners.EtherscanProvider
 	dataPath        string
 	requestLimit    int
 	requestInterval time.Duration
@@ -86,81 +88,105 @@ type BscscanWriter struct {
 	backoffFactor   float64
 	semaphore       chan struct{}
 	wg              sync.WaitGroup
-	redis           *clients.Redis
+	store           db.Store
 	clickhouseDb    *db.ClickHouse
 	ethClient       *clients.EthClient
+	verifier        *verifier.Verifier
+	compiler        verifier.Compiler
 	chainId         *big.Int
 }
 
-// WithScanner sets the BscScanProvider scanner for the BscscanWriter.
-func WithScanner(scanner *scanners.BscScanProvider) Option {
-	return func(bs *BscscanWriter) {
+// WithScanner sets the EtherscanProvider scanner for the EtherscanWriter.
+func WithScanner(scanner *scanners.EtherscanProvider) Option {
+	return func(bs *EtherscanWriter) {
 		bs.scanner = scanner
 	}
 }
 
-// WithDataPath sets the path to the CSV file containing contract data for the BscscanWriter.
+// WithDataPath sets the path to the CSV file containing contract data for the EtherscanWriter.
 func WithDataPath(dataPath string) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.dataPath = dataPath
 	}
 }
 
-// WithRequestLimit sets the maximum number of requests allowed per second for the BscscanWriter.
+// WithRequestLimit sets the maximum number of requests allowed per second for the EtherscanWriter.
 func WithRequestLimit(requestLimit int) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.requestLimit = requestLimit
 	}
 }
 
-// WithRequestInterval sets the time interval between each request for the BscscanWriter.
+// WithRequestInterval sets the time interval between each request for the EtherscanWriter.
 func WithRequestInterval(requestInterval time.Duration) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.requestInterval = requestInterval
 	}
 }
 
-// WithMaxRetry sets the maximum number of retries for the BscscanWriter.
+// WithMaxRetry sets the maximum number of retries for the EtherscanWriter.
 func WithMaxRetry(maxRetry int) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.maxRetry = maxRetry
 	}
 }
 
-// WithBackoffFactor sets the backoff factor for exponential backoff for the BscscanWriter.
+// WithBackoffFactor sets the backoff factor for exponential backoff for the EtherscanWriter.
 func WithBackoffFactor(backoffFactor float64) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.backoffFactor = backoffFactor
 	}
 }
 
 func WithRedis(client *clients.Redis) Option {
-	return func(c *BscscanWriter) {
-		c.redis = client
+	return func(c *EtherscanWriter) {
+		c.store = client
+	}
+}
+
+// WithBadger sets the BadgerDB the EtherscanWriter stores into instead of Redis, so that no external services are needed.
+func WithBadger(bdb *db.BadgerDB) Option {
+	return func(c *EtherscanWriter) {
+		c.store = db.NewBadgerStore(bdb)
+	}
+}
+
+// WithStore sets the key-value store the EtherscanWriter stores into.
+func WithStore(store db.Store) Option {
+	return func(c *EtherscanWriter) {
+		c.store = store
 	}
 }
 
 func WithClickHouseDb(clickhouseDb *db.ClickHouse) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.clickhouseDb = clickhouseDb
 	}
 }
 
 func WithEthClient(client *clients.EthClient) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.ethClient = client
 	}
 }
 
+// WithCompiler sets the compiler the explorer sources are compiled with before they are verified against the chain.
+// Without the compiler contracts are stored unverified.
+func WithCompiler(compiler verifier.Compiler) Option {
+	return func(bs *EtherscanWriter) {
+		bs.compiler = compiler
+	}
+}
+
 func WithChainID(chainID *big.Int) Option {
-	return func(bs *BscscanWriter) {
+	return func(bs *EtherscanWriter) {
 		bs.chainId = chainID
 	}
 }
 
-// New creates a new BscscanWriter with the provided options.
-func NewVerifiedContractsWritter(ctx context.Context, opts ...Option) *BscscanWriter {
-	writer := &BscscanWriter{
+// New creates a new EtherscanWriter with the provided options.
+func NewVerifiedContractsWritter(ctx context.Context, opts ...Option) *EtherscanWriter {
+	writer := &EtherscanWriter{
 		ctx:             ctx,
 		requestLimit:    defaultRequestLimit,
 		requestInterval: defaultRequestInterval,
@@ -174,10 +200,62 @@ func NewVerifiedContractsWritter(ctx context.Context, opts ...Option) *BscscanWr
 
 	writer.semaphore = make(chan struct{}, writer.requestLimit)
 
+	// Verifier only fails without the eth client, in which case contracts are stored unverified.
+	if v, err := verifier.NewVerifier(ctx, verifier.WithEthClient(writer.ethClient)); err == nil {
+		writer.verifier = v
+	}
+
 	return writer
 }
 
-func (bs *BscscanWriter) GatherVerifiedContracts() ([]CsvContract, error) {
+// verify compiles the explorer sources and compares the result with the runtime bytecode deployed on chain,
+// as explorer data is not trusted blindly. Failures are only logged and the contract is stored unverified.
+func (bs *EtherscanWriter) verify(contract *types.Contract, contractResult *scanners.EtherscanContract) {
+	if bs.verifier == nil || bs.compiler == nil {
+		return
+	}
+
+	source, err := contractResult.ParseSourceCode(bs.chainId, contract.Address)
+	if err != nil {
+		zap.L().Error(
+			ErrFailedParseSourceCode.Error(),
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	compiled, err := bs.compiler.Compile(bs.ctx, source, contractResult.CompilerVersion, contractResult.Name)
+	if err != nil {
+		zap.L().Error(
+			ErrFailedCompileContract.Error(),
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	match, err := bs.verifier.VerifyCompiled(contract, compiled.RuntimeBytecode, compiled.ImmutableReferences)
+	if err != nil {
+		zap.L().Error(
+			ErrFailedVerifyContract.Error(),
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	contract.VerificationStatus = string(match)
+
+	if match == verifier.MatchTypeMismatch {
+		zap.L().Warn(
+			"Contract bytecode does not match the verified source",
+			zap.String("contract_address", contract.Address.Hex()),
+		)
+	}
+}
+
+func (bs *EtherscanWriter) GatherVerifiedContracts() ([]CsvContract, error) {
 	file, err := os.Open(bs.dataPath)
 	if err != nil {
 		return nil, err
@@ -214,7 +292,7 @@ func (bs *BscscanWriter) GatherVerifiedContracts() ([]CsvContract, error) {
 	return contracts, nil
 }
 
-func (bs *BscscanWriter) ProcessVerifiedContracts(contracts []CsvContract) error {
+func (bs *EtherscanWriter) ProcessVerifiedContracts(contracts []CsvContract) error {
 	if len(contracts) < 1 {
 		return ErrNoContractsToProcess
 	}
@@ -257,7 +335,7 @@ func (bs *BscscanWriter) ProcessVerifiedContracts(contracts []CsvContract) error
 	return nil
 }
 
-func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) error {
+func (bs *EtherscanWriter) tryScanContract(ctx context.Context, c CsvContract) error {
 	for i := 0; i < bs.maxRetry; i++ {
 		select {
 		case <-ctx.Done():
@@ -265,7 +343,7 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 		default:
 			key := types.GetContractStorageKey(bs.chainId, c.ContractAddress)
 
-			exists, err := bs.redis.Exists(bs.ctx, key)
+			exists, err := bs.store.Exists(bs.ctx, key)
 			if err != nil {
 				zap.L().Error(
 					ErrFailedCheckExistenceInBadger.Error(),
@@ -324,10 +402,10 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 				continue
 			}
 
-			contractResult, err := bs.scanner.ScanContract(c.ContractAddress.Hex())
-
+			contractResult, err := bs.scanner.ScanContract(ctx, c.ContractAddress.Hex())
 			if err != nil {
-				if strings.Contains(err.Error(), ErrMaxRateLimitReached.Error()) {
+				// Provider already retried with backoff, rate limited contract is given one more chance later on.
+				if errors.Is(err, scanners.ErrRateLimited) {
 					zap.L().Error(
 						ErrMaxRateLimitReached.Error(),
 						zap.String("contract_address", c.ContractAddress.Hex()),
@@ -336,14 +414,14 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 					)
 					time.Sleep(time.Duration(int64(math.Pow(float64(bs.backoffFactor), float64(i)))) * time.Second)
 					continue
-				} else {
-					zap.L().Error(
-						ErrFailedGetContractInfo.Error(),
-						zap.String("contract_address", c.ContractAddress.Hex()),
-						zap.Error(err),
-					)
-					return err
 				}
+
+				zap.L().Error(
+					ErrFailedGetContractInfo.Error(),
+					zap.String("contract_address", c.ContractAddress.Hex()),
+					zap.Error(err),
+				)
+				return err
 			}
 
 			contract := &types.Contract{
@@ -380,10 +458,17 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 				}(),
 				SourceCode:         contractResult.SourceCode,
 				ABI:                contractResult.ABI,
-				VerificationType:   types.ContractVerificationTypeBscscan,
-				VerificationStatus: "perfect", // This is what sourcify returns so we'll just use it
+				VerificationType:   bs.scanner.Explorer().VerificationType,
+				VerificationStatus: "unknown",
 			}
 
+			// Creation input carries the constructor arguments, factory deployments carry no creation input.
+			if tx.To() == nil {
+				contract.CreationInput = tx.Data()
+			}
+
+			bs.verify(contract, contractResult)
+
 			if len(contractResult.SwarmSource) > 0 {
 				contract.SourceUrls = append(contract.SourceUrls, contractResult.SwarmSource)
 			}
@@ -403,6 +488,54 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 				if err := models.InsertContract(bs.ctx, bs.clickhouseDb, contract); err != nil {
 					return err
 				}
+
+				// Multi-file and standard JSON sources are stored as the source tree next to the verbatim source code.
+				source, err := contractResult.ParseSourceCode(bs.chainId, c.ContractAddress)
+				if err != nil {
+					zap.L().Error(
+						ErrFailedParseSourceCode.Error(),
+						zap.String("contract_address", c.ContractAddress.Hex()),
+						zap.Error(err),
+					)
+				} else if err := models.InsertContractSource(bs.ctx, bs.clickhouseDb, source); err != nil {
+					zap.L().Error(
+						ErrFailedToInsertContractSource.Error(),
+						zap.String("contract_address", c.ContractAddress.Hex()),
+						zap.Error(err),
+					)
+					return err
+				}
+
+				// Explorer reports implementation of the proxies it detected, keep the link for later decoding.
+				if common.IsHexAddress(contractResult.Implementation) {
+					proxy := &types.Proxy{
+						UUID:           uuid.New(),
+						ChainID:        bs.chainId,
+						Address:        c.ContractAddress,
+						Implementation: common.HexToAddress(contractResult.Implementation),
+						Type:           types.ProxyTypeUnknown,
+					}
+
+					// Link is only the decoding hint, failing to store it must not abort the contract write.
+					exists, err := models.ProxyExists(bs.ctx, bs.clickhouseDb, proxy)
+					if err != nil {
+						zap.L().Error(
+							ErrFailedToCheckIfProxyExists.Error(),
+							zap.String("contract_address", c.ContractAddress.Hex()),
+							zap.String("implementation", contractResult.Implementation),
+							zap.Error(err),
+						)
+					} else if !exists {
+						if err := models.InsertProxy(bs.ctx, bs.clickhouseDb, proxy); err != nil {
+							zap.L().Error(
+								ErrFailedToInsertProxy.Error(),
+								zap.String("contract_address", c.ContractAddress.Hex()),
+								zap.String("implementation", contractResult.Implementation),
+								zap.Error(err),
+							)
+						}
+					}
+				}
 			}
 
 			// Process abi methods, events, resulting contract mappings and write them into the database for future use
@@ -415,7 +548,7 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 				return err
 			}
 
-			err = bs.redis.Write(bs.ctx, key, resBytes, 0)
+			err = bs.store.Write(bs.ctx, key, resBytes, 0)
 			if err != nil {
 				zap.L().Error(
 					ErrFailedWriteContractInfoToDB.Error(),
@@ -432,7 +565,7 @@ func (bs *BscscanWriter) tryScanContract(ctx context.Context, c CsvContract) err
 	return ErrExceededMaxRetryAttempts
 }
 
-func (bs *BscscanWriter) processAbi(ctx context.Context, contractResult *types.Contract) error {
+func (bs *EtherscanWriter) processAbi(ctx context.Context, contractResult *types.Contract) error {
 	abi, err := abi.JSON(strings.NewReader(contractResult.ABI))
 	if err != nil {
 		zap.L().Error(
@@ -443,7 +576,7 @@ func (bs *BscscanWriter) processAbi(ctx context.Context, contractResult *types.C
 		return err
 	}
 
-	// Process abi methods and events and write them into the database for future use
+	// Process abi methods, events and errors and write them into the database for future use
 	if err := bs.processAbiMethods(contractResult, abi.Methods); err != nil {
 		zap.L().Error(
 			ErrFailedProcessAbiMethods.Error(),
@@ -462,10 +595,19 @@ func (bs *BscscanWriter) processAbi(ctx context.Context, contractResult *types.C
 		return err
 	}
 
+	if err := bs.processAbiErrors(ctx, contractResult, abi.Errors); err != nil {
+		zap.L().Error(
+			ErrFailedProcessAbiErrors.Error(),
+			zap.String("contract_address", contractResult.Address.Hex()),
+			zap.Error(err),
+		)
+		return err
+	}
+
 	return nil
 }
 
-func (bs *BscscanWriter) processAbiMethods(contractResult *types.Contract, methods map[string]abi.Method) error {
+func (bs *EtherscanWriter) processAbiMethods(contractResult *types.Contract, methods map[string]abi.Method) error {
 	for _, method := range methods {
 		select {
 		case <-bs.ctx.Done():
@@ -474,7 +616,18 @@ func (bs *BscscanWriter) processAbiMethods(contractResult *types.Contract, metho
 			methodKey := types.GetMethodStorageKey(bs.chainId, method.ID)
 			methodMapperKey := types.GetMethodMapperStorageKey(bs.chainId, method.ID)
 
-			exists, err := bs.redis.Exists(bs.ctx, methodKey)
+			// Verified methods are kept among the selector candidates even when colliding 4byte method was stored first.
+			if _, err := helpers.AppendMethodCandidate(bs.ctx, bs.store, bs.chainId, types.NewFullMethod(method)); err != nil {
+				zap.L().Error(
+					ErrFailedToAppendMethodCandidate.Error(),
+					zap.String("contract_address", contractResult.Address.Hex()),
+					zap.String("method_name", method.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+
+			exists, err := bs.store.Exists(bs.ctx, methodKey)
 			if err != nil {
 				zap.L().Error(
 					ErrFailedCheckExistenceInBadger.Error(),
@@ -550,7 +703,7 @@ func (bs *BscscanWriter) processAbiMethods(contractResult *types.Contract, metho
 				return err
 			}
 
-			if err := bs.redis.Write(bs.ctx, methodKey, methodBytes, 0); err != nil {
+			if err := bs.store.Write(bs.ctx, methodKey, methodBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedWriteMethodInfoToDB.Error(),
 					zap.String("contract_address", contractResult.Address.Hex()),
@@ -560,7 +713,7 @@ func (bs *BscscanWriter) processAbiMethods(contractResult *types.Contract, metho
 				return err
 			}
 
-			if err := bs.redis.Write(bs.ctx, methodMapperKey, methodMappingBytes, 0); err != nil {
+			if err := bs.store.Write(bs.ctx, methodMapperKey, methodMappingBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedWriteMethodMappingInfoToDB.Error(),
 					zap.String("contract_address", contractResult.Address.Hex()),
@@ -575,7 +728,7 @@ func (bs *BscscanWriter) processAbiMethods(contractResult *types.Contract, metho
 	return nil
 }
 
-func (bs *BscscanWriter) processAbiEvents(ctx context.Context, contractResult *types.Contract, events map[string]abi.Event) error {
+func (bs *EtherscanWriter) processAbiEvents(ctx context.Context, contractResult *types.Contract, events map[string]abi.Event) error {
 	for _, event := range events {
 		select {
 		case <-ctx.Done():
@@ -584,7 +737,7 @@ func (bs *BscscanWriter) processAbiEvents(ctx context.Context, contractResult *t
 			eventKey := types.GetEventStorageKey(bs.chainId, event.ID)
 			eventMappingKey := types.GetEventMapperStorageKey(bs.chainId, event.ID)
 
-			exists, err := bs.redis.Exists(bs.ctx, eventKey)
+			exists, err := bs.store.Exists(bs.ctx, eventKey)
 			if err != nil {
 				zap.L().Error(
 					ErrFailedCheckEventExistenceInBadger.Error(),
@@ -660,7 +813,7 @@ func (bs *BscscanWriter) processAbiEvents(ctx context.Context, contractResult *t
 				return err
 			}
 
-			if err := bs.redis.Write(bs.ctx, eventKey, eventBytes, 0); err != nil {
+			if err := bs.store.Write(bs.ctx, eventKey, eventBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedToWriteEventInfoToRedis.Error(),
 					zap.String("contract_address", contractResult.Address.Hex()),
@@ -681,7 +834,7 @@ func (bs *BscscanWriter) processAbiEvents(ctx context.Context, contractResult *t
 				return err
 			}
 
-			if err := bs.redis.Write(bs.ctx, eventMappingKey, eventMapperBytes, 0); err != nil {
+			if err := bs.store.Write(bs.ctx, eventMappingKey, eventMapperBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedToWriteEventMappingInfoToRedis.Error(),
 					zap.String("contract_address", contractResult.Address.Hex()),
@@ -696,3 +849,80 @@ func (bs *BscscanWriter) processAbiEvents(ctx context.Context, contractResult *t
 
 	return nil
 }
+
+func (bs *EtherscanWriter) processAbiErrors(ctx context.Context, contractResult *types.Contract, abiErrors map[string]abi.Error) error {
+	for _, abiError := range abiErrors {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			errorKey := types.GetErrorStorageKey(bs.chainId, abiError.ID[:4])
+
+			exists, err := bs.store.Exists(bs.ctx, errorKey)
+			if err != nil {
+				zap.L().Error(
+					ErrFailedCheckErrorExistenceInRedis.Error(),
+					zap.String("contract_address", contractResult.Address.Hex()),
+					zap.String("error_name", abiError.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+
+			// Just skip if the error already exists in Redis
+			if exists {
+				continue
+			}
+
+			errorResult := types.NewFullError(abiError)
+
+			// Write into clickhouse but only if clickhouse database is set
+			if bs.clickhouseDb != nil {
+				errorExists, err := models.ErrorExists(bs.ctx, bs.clickhouseDb, errorResult)
+				if err != nil {
+					zap.L().Error(
+						ErrFailedToCheckIfErrorExists.Error(),
+						zap.String("error_name", abiError.Name),
+						zap.Error(err),
+					)
+					continue
+				}
+
+				if !errorExists {
+					if err := models.InsertError(bs.ctx, bs.clickhouseDb, errorResult); err != nil {
+						zap.L().Error(
+							ErrFailedToInsertError.Error(),
+							zap.Error(err),
+							zap.String("contract_address", contractResult.Address.Hex()),
+							zap.String("error_name", abiError.Name),
+						)
+						return err
+					}
+				}
+			}
+
+			errorBytes, err := errorResult.MarshalBytes()
+			if err != nil {
+				zap.L().Error(
+					ErrFailedToMarshalError.Error(),
+					zap.String("contract_address", contractResult.Address.Hex()),
+					zap.String("error_name", abiError.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+
+			if err := bs.store.Write(bs.ctx, errorKey, errorBytes, 0); err != nil {
+				zap.L().Error(
+					ErrFailedToWriteErrorToRedis.Error(),
+					zap.String("contract_address", contractResult.Address.Hex()),
+					zap.String("error_name", abiError.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
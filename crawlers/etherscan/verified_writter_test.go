@@ -0,0 +1,96 @@
+package etherscan
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/scanners"
+	"github.com/txpull/unpack/types"
+	"github.com/txpull/unpack/verifier"
+)
+
+// stubCompiler returns the same compiled contract for any sources and records what it was asked to compile.
+type stubCompiler struct {
+	compiled *verifier.CompiledContract
+	source   *types.ContractSource
+	version  string
+	name     string
+}
+
+func (c *stubCompiler) Compile(ctx context.Context, source *types.ContractSource, compilerVersion string, contractName string) (*verifier.CompiledContract, error) {
+	c.source, c.version, c.name = source, compilerVersion, contractName
+	return c.compiled, nil
+}
+
+func TestEtherscanWriter_Verify(t *testing.T) {
+	tAssert := assert.New(t)
+
+	compiler := &stubCompiler{compiled: &verifier.CompiledContract{RuntimeBytecode: common.FromHex("0x6080604052600080fd")}}
+
+	// Runtime bytecode is set on every contract, eth client is never reached.
+	writer := NewVerifiedContractsWritter(
+		context.TODO(),
+		WithChainID(big.NewInt(56)),
+		WithEthClient(&clients.EthClient{}),
+		WithCompiler(compiler),
+	)
+
+	contractResult := &scanners.EtherscanContract{
+		SourceCode:      "pragma solidity ^0.8.19; contract Token {}",
+		Name:            "Token",
+		CompilerVersion: "v0.8.19+commit.7dd6d404",
+	}
+
+	cases := []struct {
+		name                  string
+		runtime               []byte
+		expectedStatus        string
+		expectedProcessStatus int8
+	}{
+		{
+			name:           "match",
+			runtime:        common.FromHex("0x6080604052600080fd"),
+			expectedStatus: "perfect",
+		},
+		{
+			name:                  "mismatch",
+			runtime:               common.FromHex("0x6080604052348015"),
+			expectedStatus:        "mismatch",
+			expectedProcessStatus: helpers.CONTRACT_PROCESS_STATUS_MISMATCH,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tAssert := assert.New(t)
+
+			contract := &types.Contract{
+				Address:            common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56"),
+				RuntimeBytecode:    tc.runtime,
+				VerificationStatus: "unknown",
+			}
+
+			writer.verify(contract, contractResult)
+
+			tAssert.Equal(tc.expectedStatus, contract.VerificationStatus)
+			tAssert.Equal(tc.expectedProcessStatus, contract.ProcessStatus)
+		})
+	}
+
+	tAssert.Equal("v0.8.19+commit.7dd6d404", compiler.version)
+	tAssert.Equal("Token", compiler.name)
+	tAssert.Equal(contractResult.SourceCode, compiler.source.Sources["Token.sol"])
+
+	// Without the compiler contracts are stored unverified.
+	writer.compiler = nil
+
+	contract := &types.Contract{RuntimeBytecode: common.FromHex("0x6080604052348015"), VerificationStatus: "unknown"}
+	writer.verify(contract, contractResult)
+	tAssert.Equal("unknown", contract.VerificationStatus)
+	tAssert.Zero(contract.ProcessStatus)
+}
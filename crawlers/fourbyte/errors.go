@@ -32,4 +32,22 @@ var (
 
 	// ErrFailedToSetNextPageNumber is returned when we failed to set next page number.
 	ErrFailedToSetNextPageNumber = fmt.Errorf("failed to set next page number")
+
+	// ErrFailedToAppendMethodCandidate is returned when failed to append the method to the selector candidates.
+	ErrFailedToAppendMethodCandidate = fmt.Errorf("failed to append method candidate")
+
+	// ErrFailedToConstructNewEvent is returned when we failed to construct new event.
+	ErrFailedToConstructNewEvent = fmt.Errorf("failed to construct new event")
+
+	// ErrFailedToCheckIfEventCacheKeyExists is returned when we failed to check if event cache key exists.
+	ErrFailedToCheckIfEventCacheKeyExists = fmt.Errorf("failed to check if event cache key exists")
+
+	// ErrFailedMarshalEvent is returned when we failed to marshal event info.
+	ErrFailedMarshalEvent = fmt.Errorf("failed to marshal event")
+
+	// ErrFailedToCheckIfEventExists is returned when we failed to check if event exists in ClickHouse.
+	ErrFailedToCheckIfEventExists = fmt.Errorf("failed to check if event exists")
+
+	// ErrFailedToInsertEvent is returned when we failed to insert event into ClickHouse.
+	ErrFailedToInsertEvent = fmt.Errorf("failed to insert event into ClickHouse")
 )
@@ -22,13 +22,28 @@ import (
 // LAST_PROCESSED_PAGE_KEY is the key for the last processed page number.
 const LAST_PROCESSED_PAGE_KEY = "last_processed_fourbyte_page"
 
+// LAST_PROCESSED_EVENT_PAGE_KEY is the key for the last processed page number of event signatures.
+const LAST_PROCESSED_EVENT_PAGE_KEY = "last_processed_fourbyte_event_page"
+
+// Mode determines which kind of signatures the writer crawls.
+type Mode int
+
+const (
+	// ModeMethods crawls function signatures (https://www.4byte.directory/api/v1/signatures/).
+	ModeMethods Mode = iota
+
+	// ModeEvents crawls event signatures (https://www.4byte.directory/api/v1/event-signatures/).
+	ModeEvents
+)
+
 type FourByteWriter struct {
 	ctx          context.Context            // Context to control the crawling process.
 	provider     *scanners.FourByteProvider // Provider used to fetch pages.
-	redis        *clients.Redis             // BadgerDB instance for storing signatures.
+	store        db.Store                   // Redis or BadgerDB store for the signatures.
 	cooldown     time.Duration              // Cooldown duration between page fetches.
 	clickhouseDb *db.ClickHouse
 	chainId      *big.Int
+	mode         Mode // Kind of signatures to crawl, methods by default.
 }
 
 // WriterOption is a functional option for customizing the FourByteWriter.
@@ -42,7 +57,21 @@ func WithProvider(provider *scanners.FourByteProvider) WriterOption {
 
 func WithRedis(client *clients.Redis) WriterOption {
 	return func(c *FourByteWriter) {
-		c.redis = client
+		c.store = client
+	}
+}
+
+// WithBadger sets the BadgerDB the FourByteWriter stores into instead of Redis, so that no external services are needed.
+func WithBadger(bdb *db.BadgerDB) WriterOption {
+	return func(c *FourByteWriter) {
+		c.store = db.NewBadgerStore(bdb)
+	}
+}
+
+// WithStore sets the key-value store the FourByteWriter stores into.
+func WithStore(store db.Store) WriterOption {
+	return func(c *FourByteWriter) {
+		c.store = store
 	}
 }
 
@@ -70,6 +99,13 @@ func WithChainID(chainID *big.Int) WriterOption {
 	}
 }
 
+// WithMode sets the kind of signatures to crawl. Provider has to point to the matching 4byte endpoint.
+func WithMode(mode Mode) WriterOption {
+	return func(c *FourByteWriter) {
+		c.mode = mode
+	}
+}
+
 func NewFourByteWriter(opts ...WriterOption) *FourByteWriter {
 	writer := &FourByteWriter{
 		ctx:      context.Background(),
@@ -101,106 +137,195 @@ func (w *FourByteWriter) Crawl() error {
 
 		// Process the page content here.
 		// If processing is successful, update the last page number in the BadgerDB.
-		for _, result := range resp.Results {
-			methodName, methodArguments := helpers.ExtractFourByteMethodAndArgumentTypes(result.Text)
-
-			method, err := types.NewFourByteMethod(
-				result.Hex,
-				methodName,
-				result.Text,
-				methodArguments,
-			)
-			if err != nil {
-				// Silence invalid method length errors.
-				if !strings.Contains(err.Error(), "invalid method length") {
-					zap.L().Error(
-						ErrFailedToConstructNewMethod.Error(),
-						zap.Error(err),
-						zap.String("name", result.Text),
-					)
-				}
-				continue
-			}
+		switch w.mode {
+		case ModeEvents:
+			w.processEvents(resp.Results)
+		default:
+			w.processMethods(resp.Results)
+		}
 
-			cacheKey := types.GetMethodStorageKey(w.chainId, method.Bytes)
+		if resp.Next == "" {
+			break
+		}
 
-			exists, err := w.redis.Exists(w.ctx, cacheKey)
-			if err != nil {
+		pageNum, err = extractPageNumFromURL(resp.Next)
+		if err != nil {
+			zap.L().Error(ErrFailedToExtractPageNum.Error(), zap.Error(err))
+			return err
+		}
+
+		// Update the last page number in the Redis.
+		if err = w.setLastPageNum(pageNum); err != nil {
+			zap.L().Error(ErrFailedToSetNextPageNumber.Error(), zap.Error(err))
+			return err
+		}
+
+		// Sleep a bit between each iteration to not overload the API.
+		time.Sleep(w.cooldown)
+	}
+
+	zap.L().Info("Successfully processed all pages!", zap.Uint64("last_page_number", pageNum))
+
+	return nil
+}
+
+// processMethods stores function signatures of the page as partial methods.
+func (w *FourByteWriter) processMethods(results []scanners.FourByteResult) {
+	for _, result := range results {
+		method, err := types.NewFourByteMethod(result.Hex, result.Text)
+		if err != nil {
+			// Silence invalid method length errors.
+			if !strings.Contains(err.Error(), "invalid method length") {
 				zap.L().Error(
-					ErrFailedToCheckIfMethodCacheKeyExists.Error(),
-					zap.String("method_name", method.Name),
+					ErrFailedToConstructNewMethod.Error(),
 					zap.Error(err),
+					zap.String("name", result.Text),
 				)
-				continue
 			}
+			continue
+		}
+		method.FourByteID = int64(result.ID)
+
+		// Every signature sharing the selector is kept as the candidate, regardless of the single method below.
+		if _, err := helpers.AppendMethodCandidate(w.ctx, w.store, w.chainId, method); err != nil {
+			zap.L().Error(
+				ErrFailedToAppendMethodCandidate.Error(),
+				zap.String("method_name", method.Name),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		cacheKey := types.GetMethodStorageKey(w.chainId, method.Bytes)
+
+		exists, err := w.store.Exists(w.ctx, cacheKey)
+		if err != nil {
+			zap.L().Error(
+				ErrFailedToCheckIfMethodCacheKeyExists.Error(),
+				zap.String("method_name", method.Name),
+				zap.Error(err),
+			)
+			continue
+		}
 
-			methodBytes, err := method.MarshalBytes()
+		methodBytes, err := method.MarshalBytes()
+		if err != nil {
+			zap.L().Error(
+				ErrFailedMarshalMethod.Error(),
+				zap.String("method_name", method.Name),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		// Colliding signatures are stored in clickhouse as well, existence is checked by selector and signature.
+		if w.clickhouseDb != nil {
+			methodExists, err := models.MethodExists(w.ctx, w.clickhouseDb, method)
 			if err != nil {
 				zap.L().Error(
-					ErrFailedMarshalMethod.Error(),
+					ErrFailedToCheckIfMethodExists.Error(),
 					zap.String("method_name", method.Name),
 					zap.Error(err),
 				)
 				continue
 			}
 
-			// Alright, we don't have this signature processed yet, let's do it! :rocket:
-			if !exists {
-				methodExists, err := models.MethodExists(w.ctx, w.clickhouseDb, method)
-				if err != nil {
+			if !methodExists {
+				if err := models.InsertMethod(w.ctx, w.clickhouseDb, method); err != nil {
 					zap.L().Error(
-						ErrFailedToCheckIfMethodExists.Error(),
+						ErrFailedToInsertMethod.Error(),
 						zap.String("method_name", method.Name),
 						zap.Error(err),
 					)
 					continue
 				}
+			}
+		}
 
-				if !methodExists {
-					if err := models.InsertMethod(w.ctx, w.clickhouseDb, method); err != nil {
-						zap.L().Error(
-							ErrFailedToInsertMethod.Error(),
-							zap.String("method_name", method.Name),
-							zap.Error(err),
-						)
-						continue
-					}
-				}
-
-				if err := w.redis.Write(w.ctx, cacheKey, methodBytes, 0); err != nil {
-					zap.L().Error(
-						ErrFailedRedisWrite.Error(),
-						zap.String("method_name", method.Name),
-						zap.Error(err),
-					)
-					continue
-				}
+		// Alright, we don't have this signature processed yet, let's do it! :rocket:
+		if !exists {
+			if err := w.store.Write(w.ctx, cacheKey, methodBytes, 0); err != nil {
+				zap.L().Error(
+					ErrFailedRedisWrite.Error(),
+					zap.String("method_name", method.Name),
+					zap.Error(err),
+				)
+				continue
 			}
 		}
+	}
+}
 
-		if resp.Next == "" {
-			break
+// processEvents stores event signatures of the page as partial events.
+func (w *FourByteWriter) processEvents(results []scanners.FourByteResult) {
+	for _, result := range results {
+		event, err := types.NewFourByteEvent(result.Hex, result.Text)
+		if err != nil {
+			zap.L().Error(
+				ErrFailedToConstructNewEvent.Error(),
+				zap.Error(err),
+				zap.String("name", result.Text),
+			)
+			continue
 		}
 
-		pageNum, err = extractPageNumFromURL(resp.Next)
+		cacheKey := types.GetEventStorageKey(w.chainId, event.Hash)
+
+		exists, err := w.store.Exists(w.ctx, cacheKey)
 		if err != nil {
-			zap.L().Error(ErrFailedToExtractPageNum.Error(), zap.Error(err))
-			return err
+			zap.L().Error(
+				ErrFailedToCheckIfEventCacheKeyExists.Error(),
+				zap.String("event_name", event.Name),
+				zap.Error(err),
+			)
+			continue
 		}
 
-		// Update the last page number in the Redis.
-		if err = w.setLastPageNum(pageNum); err != nil {
-			zap.L().Error(ErrFailedToSetNextPageNumber.Error(), zap.Error(err))
-			return err
+		eventBytes, err := event.MarshalBytes()
+		if err != nil {
+			zap.L().Error(
+				ErrFailedMarshalEvent.Error(),
+				zap.String("event_name", event.Name),
+				zap.Error(err),
+			)
+			continue
 		}
 
-		// Sleep a bit between each iteration to not overload the API.
-		time.Sleep(w.cooldown)
-	}
+		if w.clickhouseDb != nil {
+			eventExists, err := models.EventExist(w.ctx, w.clickhouseDb, event.Hash)
+			if err != nil {
+				zap.L().Error(
+					ErrFailedToCheckIfEventExists.Error(),
+					zap.String("event_name", event.Name),
+					zap.Error(err),
+				)
+				continue
+			}
 
-	zap.L().Info("Successfully processed all pages!", zap.Uint64("last_page_number", pageNum))
+			if !eventExists {
+				if err := models.InsertEvent(w.ctx, w.clickhouseDb, event); err != nil {
+					zap.L().Error(
+						ErrFailedToInsertEvent.Error(),
+						zap.String("event_name", event.Name),
+						zap.Error(err),
+					)
+					continue
+				}
+			}
+		}
 
-	return nil
+		// Event could be already stored from the verified contract, which is always better than partial one.
+		if !exists {
+			if err := w.store.Write(w.ctx, cacheKey, eventBytes, 0); err != nil {
+				zap.L().Error(
+					ErrFailedRedisWrite.Error(),
+					zap.String("event_name", event.Name),
+					zap.Error(err),
+				)
+				continue
+			}
+		}
+	}
 }
 
 // getLastPageNum retrieves the last processed page number from BadgerDB.
@@ -210,13 +335,13 @@ func (w *FourByteWriter) Crawl() error {
 // If the key is not found, it returns 0 as the last page number.
 func (w *FourByteWriter) getLastPageNum() (uint64, error) {
 	pageNum := uint64(1)
-	exists, err := w.redis.Exists(w.ctx, LAST_PROCESSED_PAGE_KEY)
+	exists, err := w.store.Exists(w.ctx, w.pageKey())
 	if err != nil {
 		return 0, err
 	}
 
 	if exists {
-		val, err := w.redis.Get(w.ctx, LAST_PROCESSED_PAGE_KEY)
+		val, err := w.store.Get(w.ctx, w.pageKey())
 		if err != nil {
 			return 0, err
 		}
@@ -229,7 +354,17 @@ func (w *FourByteWriter) getLastPageNum() (uint64, error) {
 func (w *FourByteWriter) setLastPageNum(pageNum uint64) error {
 	val := make([]byte, 8)
 	binary.BigEndian.PutUint64(val, pageNum)
-	return w.redis.Write(w.ctx, LAST_PROCESSED_PAGE_KEY, val, 0)
+	return w.store.Write(w.ctx, w.pageKey(), val, 0)
+}
+
+// pageKey returns the key of the last processed page number for the crawled kind of signatures,
+// so that methods and events crawling can be resumed independently.
+func (w *FourByteWriter) pageKey() string {
+	if w.mode == ModeEvents {
+		return LAST_PROCESSED_EVENT_PAGE_KEY
+	}
+
+	return LAST_PROCESSED_PAGE_KEY
 }
 
 // extractPageNumFromURL extracts the page number from a URL.
@@ -0,0 +1,55 @@
+package sourcify
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/sourcify-go"
+	"github.com/txpull/unpack/verifier"
+)
+
+// compiledContractResponse represents the subset of the Sourcify contract lookup we are interested in.
+type compiledContractResponse struct {
+	RuntimeBytecode struct {
+		RecompiledBytecode  string                       `json:"recompiledBytecode"`
+		ImmutableReferences verifier.ImmutableReferences `json:"immutableReferences"`
+	} `json:"runtimeBytecode"`
+}
+
+// getCompiledContract fetches the runtime bytecode Sourcify compiled the verified sources into, together with
+// the immutable references reported by the compiler.
+func getCompiledContract(client *sourcify.Client, chainID *big.Int, address common.Address) (*verifier.CompiledContract, error) {
+	method := sourcify.Method{
+		Name:      "Get verified contract",
+		URI:       fmt.Sprintf("/v2/contract/%s/%s", chainID.String(), address.Hex()),
+		MoreInfo:  "https://sourcify.dev/server/api-docs/",
+		Method:    "GET",
+		ParamType: sourcify.MethodParamTypeQueryString,
+		Params:    []sourcify.MethodParam{{Key: "fields", Value: "runtimeBytecode"}},
+	}
+
+	response, statusCode, err := client.CallMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	// CallMethod is not closing the response body.
+	defer response.Close()
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", statusCode)
+	}
+
+	var contract compiledContractResponse
+	if err := json.NewDecoder(response).Decode(&contract); err != nil {
+		return nil, fmt.Errorf("failed to decode compiled contract: %w", err)
+	}
+
+	return &verifier.CompiledContract{
+		RuntimeBytecode:     common.FromHex(contract.RuntimeBytecode.RecompiledBytecode),
+		ImmutableReferences: contract.RuntimeBytecode.ImmutableReferences.List(),
+	}, nil
+}
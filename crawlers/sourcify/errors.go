@@ -9,6 +9,9 @@ var (
 	// ErrFailedGetTransactionReceiptByHash is returned when we fail to get a transaction receipt by hash
 	ErrFailedGetTransactionReceiptByHash = errors.New("failed to get transaction receipt by hash")
 
+	// ErrFailedLocateContractCreation is returned when we fail to locate the contract creation transaction
+	ErrFailedLocateContractCreation = errors.New("failed to locate contract creation")
+
 	// ErrFailedToWriteContractToDatabase is returned when we fail to write a contract to the database
 	ErrFailedToWriteContractToDatabase = errors.New("failed to write contract to database")
 
@@ -83,4 +86,37 @@ var (
 
 	// ErrFailedContractValidationCheck is returned when we fail to validate a contract
 	ErrFailedContractValidationCheck = errors.New("failed to validate contract")
+
+	// ErrFailedProcessAbiErrors is returned when failed to process ABI custom errors.
+	ErrFailedProcessAbiErrors = errors.New("failed to process ABI errors")
+
+	// ErrFailedCheckErrorExistenceInRedis is returned when failed to check existence of custom error information in redis.
+	ErrFailedCheckErrorExistenceInRedis = errors.New("failed to check existence of error information in redis")
+
+	// ErrFailedToCheckIfErrorExists is returned when failed to check if custom error exists.
+	ErrFailedToCheckIfErrorExists = errors.New("failed to check if error exists")
+
+	// ErrFailedToInsertError is returned when failed to insert custom error.
+	ErrFailedToInsertError = errors.New("failed to insert error")
+
+	// ErrFailedToMarshalError is returned when failed to marshal custom error information to binary.
+	ErrFailedToMarshalError = errors.New("failed to marshal error information to binary")
+
+	// ErrFailedToWriteErrorToRedis is returned when failed to write custom error information to redis.
+	ErrFailedToWriteErrorToRedis = errors.New("failed to write error information to redis")
+
+	// ErrFailedParseSourceCode is returned when failed to parse the source code returned by the explorer.
+	ErrFailedParseSourceCode = errors.New("failed to parse source code")
+
+	// ErrFailedToInsertContractSource is returned when failed to insert the contract source tree.
+	ErrFailedToInsertContractSource = errors.New("failed to insert contract source")
+
+	// ErrFailedVerifyContract is returned when we fail to verify the contract bytecode against the chain
+	ErrFailedVerifyContract = errors.New("failed to verify contract bytecode")
+
+	// ErrFailedGetCompiledContract is returned when we fail to get the bytecode Sourcify compiled the contract into
+	ErrFailedGetCompiledContract = errors.New("failed to get compiled contract")
+
+	// ErrFailedToAppendMethodCandidate is returned when failed to append the method to the selector candidates.
+	ErrFailedToAppendMethodCandidate = errors.New("failed to append method candidate")
 )
@@ -3,7 +3,6 @@ package sourcify
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"strings"
 
@@ -11,29 +10,26 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/txpull/sourcify-go"
 	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/creations"
 	"github.com/txpull/unpack/db"
 	"github.com/txpull/unpack/db/models"
 	"github.com/txpull/unpack/helpers"
 	"github.com/txpull/unpack/scanners"
 	"github.com/txpull/unpack/types"
+	"github.com/txpull/unpack/verifier"
 	"go.uber.org/zap"
 )
 
-var (
-	queryChainToName = map[int64]string{
-		1:  "ethereum",
-		56: "bsc",
-	}
-)
-
 type SourcifyWriter struct {
 	ctx          context.Context  // Context to control the crawling process.
 	provider     *sourcify.Client // Provider used to fetch pages.
-	redis        *clients.Redis   // BadgerDB instance for storing signatures.
+	store        db.Store         // Redis or BadgerDB store for the signatures.
 	clickhouseDb *db.ClickHouse
 	bitquery     *scanners.BitQueryProvider
 	ethClient    *clients.EthClient
-	bscscan      *scanners.BscScanProvider
+	etherscan    *scanners.EtherscanProviders
+	locator      *creations.Locator
+	verifier     *verifier.Verifier
 	chainId      *big.Int
 }
 
@@ -48,14 +44,28 @@ func WithSourcify(provider *sourcify.Client) WriterOption {
 
 func WithRedis(client *clients.Redis) WriterOption {
 	return func(c *SourcifyWriter) {
-		c.redis = client
+		c.store = client
+	}
+}
+
+// WithBadger sets the BadgerDB the SourcifyWriter stores into instead of Redis, so that no external services are needed.
+func WithBadger(bdb *db.BadgerDB) WriterOption {
+	return func(c *SourcifyWriter) {
+		c.store = db.NewBadgerStore(bdb)
+	}
+}
+
+// WithStore sets the key-value store the SourcifyWriter stores into.
+func WithStore(store db.Store) WriterOption {
+	return func(c *SourcifyWriter) {
+		c.store = store
 	}
 }
 
-// WithBscScan sets the BscScanProvider scanner for the SourcifyWriter.
-func WithBscScan(scanner *scanners.BscScanProvider) WriterOption {
+// WithEtherscan sets the Etherscan-compatible scanners, keyed by the chain ID, for the SourcifyWriter.
+func WithEtherscan(providers *scanners.EtherscanProviders) WriterOption {
 	return func(w *SourcifyWriter) {
-		w.bscscan = scanner
+		w.etherscan = providers
 	}
 }
 
@@ -77,6 +87,7 @@ func WithChainID(chainID *big.Int) WriterOption {
 	}
 }
 
+// WithBitQuery sets the optional BitQuery provider used to speed up the contract creation lookups.
 func WithBitQuery(bq *scanners.BitQueryProvider) WriterOption {
 	return func(c *SourcifyWriter) {
 		c.bitquery = bq
@@ -96,9 +107,61 @@ func NewSourcifyWriter(opts ...WriterOption) *SourcifyWriter {
 	for _, opt := range opts {
 		opt(writer)
 	}
+
+	locatorOpts := []creations.Option{creations.WithEthClient(writer.ethClient)}
+	if writer.bitquery != nil {
+		locatorOpts = append(locatorOpts, creations.WithBitQuery(writer.bitquery))
+	}
+
+	// Locator only fails without the eth client, in which case creations are simply not looked up.
+	if locator, err := creations.NewLocator(writer.ctx, locatorOpts...); err == nil {
+		writer.locator = locator
+	}
+
+	if v, err := verifier.NewVerifier(writer.ctx, verifier.WithEthClient(writer.ethClient)); err == nil {
+		writer.verifier = v
+	}
+
 	return writer
 }
 
+// verify compares the runtime bytecode Sourcify compiled the sources into with the runtime bytecode deployed on
+// chain. Status reported by Sourcify is kept, mismatches are flagged in the process status only. Failures are
+// only logged and the contract is stored as reported by Sourcify.
+func (w *SourcifyWriter) verify(chainID *big.Int, contract *types.Contract) {
+	if w.verifier == nil {
+		return
+	}
+
+	compiled, err := getCompiledContract(w.provider, chainID, contract.Address)
+	if err != nil {
+		zap.L().Error(
+			ErrFailedGetCompiledContract.Error(),
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	match, err := w.verifier.VerifyCompiled(contract, compiled.RuntimeBytecode, compiled.ImmutableReferences)
+	if err != nil {
+		zap.L().Error(
+			ErrFailedVerifyContract.Error(),
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if match == verifier.MatchTypeMismatch {
+		zap.L().Warn(
+			"Contract bytecode does not match the verified source",
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.String("sourcify_status", contract.VerificationStatus),
+		)
+	}
+}
+
 func (w *SourcifyWriter) GetContractListByChainID(chainID *big.Int) (*sourcify.VerifiedContractAddresses, error) {
 	return sourcify.GetAvailableContractAddresses(w.provider, int(chainID.Int64()))
 }
@@ -121,7 +184,7 @@ func (w *SourcifyWriter) ProcessContractsByType(chainID *big.Int, contracts *sou
 
 		cacheKey := types.GetContractStorageKey(w.chainId, address)
 
-		exists, err := w.redis.Exists(w.ctx, cacheKey)
+		exists, err := w.store.Exists(w.ctx, cacheKey)
 		if err != nil {
 			zap.L().Error(
 				ErrFailedToCheckIfMethodCacheKeyExists.Error(),
@@ -218,55 +281,26 @@ func (w *SourcifyWriter) ProcessContractsByType(chainID *big.Int, contracts *sou
 		}
 
 		// Now once we have all of the information that we are interested in
-		// it's time to do some kebabs, and figure out block, transaction of the contract...
-		// TODO: Figure out how to do this in a better way...
-		queryData := map[string]string{
-			"query": fmt.Sprintf(`{
-			smartContractCreation: ethereum(network: %s) {
-			  smartContractCalls(
-				smartContractAddress: {is: "%s"}
-				smartContractMethod: {is: "Contract Creation"}
-			  ) {
-				transaction {
-				  hash
-				}
-				block {
-				  height
-				}
-			  }
-			}
-		  }`, queryChainToName[chainID.Int64()], address.Hex()),
-		}
-
-		bitqueryInfo, err := w.bitquery.GetContractCreationInfo(queryData)
-		if err != nil {
-			zap.L().Error(
-				"failed to get contract creation info from bitquery",
-				zap.String("contract_address", address.Hex()),
-				zap.Error(err),
-			)
-			continue
-		}
-
-		if len(bitqueryInfo.Data.SmartContractCreation.SmartContractCalls) > 0 {
-			contract.TransactionHash = common.HexToHash(
-				bitqueryInfo.Data.SmartContractCreation.SmartContractCalls[0].Transaction.Hash,
-			)
-
-			txReceipt, err := helpers.GetReceiptByHash(w.ctx, w.ethClient, contract.TransactionHash)
+		// it's time to figure out block, transaction and creation bytecode of the contract...
+		if w.locator != nil {
+			creation, err := w.locator.Locate(chainID, address)
 			if err != nil {
+				// Creation details are not required to store the contract, decoder retries the lookup later on.
 				zap.L().Error(
-					ErrFailedGetTransactionReceiptByHash.Error(),
+					ErrFailedLocateContractCreation.Error(),
 					zap.String("contract_address", address.Hex()),
-					zap.String("tx_hash", contract.TransactionHash.Hex()),
 					zap.Error(err),
 				)
-				continue
+			} else {
+				contract.TransactionHash = creation.TransactionHash
+				contract.BlockHash = creation.BlockHash
+				contract.CreationInput = creation.Bytecode
 			}
-
-			contract.BlockHash = txReceipt.BlockHash
 		}
 
+		// Sourcify match is re-checked against the chain so that stale or mis-attributed sources are flagged.
+		w.verify(chainID, contract)
+
 		// Figure out if we have constructor abi...
 		for _, abi := range metadata.Output.Abi {
 			if abi.Type == "constructor" {
@@ -284,15 +318,24 @@ func (w *SourcifyWriter) ProcessContractsByType(chainID *big.Int, contracts *sou
 		}
 
 		// Now this is a bit of a hack to attempt filling up the contract information in case
-		// sourcify is missing information and tools such as bscscan or etherscan have it...
-		// TODO: Etherscan...
+		// sourcify is missing information and explorers such as etherscan or bscscan have it...
 		// TODO: Seems that both BSCScan and Sourcify have the same source code but no ABI. See: 0x005D5631EF919DcDa961f0DE1539d62E3f0eBf37
+		var source *types.ContractSource
 		if contract.SourceCode == "" || contract.ABI == "" || contract.LicenseType == "" ||
 			contract.Name == "" || contract.ConstructorArguments == "" || contract.Proxy == "" {
-			contractResult, err := w.bscscan.ScanContract(contract.Address.Hex())
+			contractResult, err := w.etherscan.ScanContract(w.ctx, chainID, contract.Address.Hex())
 			if err == nil {
 				if contractResult.SourceCode != "" && contract.SourceCode == "" {
 					contract.SourceCode = contractResult.SourceCode
+
+					source, err = contractResult.ParseSourceCode(chainID, contract.Address)
+					if err != nil {
+						zap.L().Error(
+							ErrFailedParseSourceCode.Error(),
+							zap.String("contract_address", address.Hex()),
+							zap.Error(err),
+						)
+					}
 				}
 
 				if contractResult.ABI != "" && (contract.ABI == "" || contract.ABI == "[]") {
@@ -303,7 +346,7 @@ func (w *SourcifyWriter) ProcessContractsByType(chainID *big.Int, contracts *sou
 					contract.LicenseType = contractResult.LicenseType
 				}
 
-				// One from BSCScan is more reliable...
+				// One from the explorer is more reliable...
 				if contractResult.Name != contract.Name {
 					contract.Name = contractResult.Name
 				}
@@ -333,6 +376,18 @@ func (w *SourcifyWriter) ProcessContractsByType(chainID *big.Int, contracts *sou
 			continue
 		}
 
+		// Source tree is known only when the source code came from the explorer.
+		if source != nil && w.clickhouseDb != nil {
+			if err := models.InsertContractSource(w.ctx, w.clickhouseDb, source); err != nil {
+				zap.L().Error(
+					ErrFailedToInsertContractSource.Error(),
+					zap.String("contract_address", address.Hex()),
+					zap.Error(err),
+				)
+				continue
+			}
+		}
+
 	}
 
 	return nil
@@ -341,7 +396,7 @@ func (w *SourcifyWriter) ProcessContractsByType(chainID *big.Int, contracts *sou
 func (w *SourcifyWriter) WriteContract(contract *types.Contract) error {
 	cacheKey := types.GetContractStorageKey(w.chainId, contract.Address)
 
-	exists, err := w.redis.Exists(w.ctx, cacheKey)
+	exists, err := w.store.Exists(w.ctx, cacheKey)
 	if err != nil {
 		zap.L().Error(
 			ErrFailedToCheckIfMethodCacheKeyExists.Error(),
@@ -382,7 +437,7 @@ func (w *SourcifyWriter) WriteContract(contract *types.Contract) error {
 		return err
 	}
 
-	err = w.redis.Write(w.ctx, cacheKey, resBytes, 0)
+	err = w.store.Write(w.ctx, cacheKey, resBytes, 0)
 	if err != nil {
 		zap.L().Error(
 			ErrFailedWriteContractToRedis.Error(),
@@ -406,7 +461,7 @@ func (w *SourcifyWriter) processAbi(ctx context.Context, contract *types.Contrac
 		return err
 	}
 
-	// Process abi methods and events and write them into the database for future use
+	// Process abi methods, events and errors and write them into the database for future use
 	if err := w.processAbiMethods(contract, abi.Methods); err != nil {
 		zap.L().Error(
 			ErrFailedProcessAbiMethods.Error(),
@@ -425,6 +480,15 @@ func (w *SourcifyWriter) processAbi(ctx context.Context, contract *types.Contrac
 		return err
 	}
 
+	if err := w.processAbiErrors(ctx, contract, abi.Errors); err != nil {
+		zap.L().Error(
+			ErrFailedProcessAbiErrors.Error(),
+			zap.String("contract_address", contract.Address.Hex()),
+			zap.Error(err),
+		)
+		return err
+	}
+
 	return nil
 }
 
@@ -437,7 +501,18 @@ func (w *SourcifyWriter) processAbiMethods(contract *types.Contract, methods map
 			methodKey := types.GetMethodStorageKey(w.chainId, method.ID)
 			methodMapperKey := types.GetMethodMapperStorageKey(w.chainId, method.ID)
 
-			exists, err := w.redis.Exists(w.ctx, methodKey)
+			// Verified methods are kept among the selector candidates even when colliding 4byte method was stored first.
+			if _, err := helpers.AppendMethodCandidate(w.ctx, w.store, w.chainId, types.NewFullMethod(method)); err != nil {
+				zap.L().Error(
+					ErrFailedToAppendMethodCandidate.Error(),
+					zap.String("contract_address", contract.Address.Hex()),
+					zap.String("method_name", method.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+
+			exists, err := w.store.Exists(w.ctx, methodKey)
 			if err != nil {
 				zap.L().Error(
 					ErrFailedToReadRedis.Error(),
@@ -513,7 +588,7 @@ func (w *SourcifyWriter) processAbiMethods(contract *types.Contract, methods map
 				return err
 			}
 
-			if err := w.redis.Write(w.ctx, methodKey, methodBytes, 0); err != nil {
+			if err := w.store.Write(w.ctx, methodKey, methodBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedWriteMethodToRedis.Error(),
 					zap.String("contract_address", contract.Address.Hex()),
@@ -523,7 +598,7 @@ func (w *SourcifyWriter) processAbiMethods(contract *types.Contract, methods map
 				return err
 			}
 
-			if err := w.redis.Write(w.ctx, methodMapperKey, methodMappingBytes, 0); err != nil {
+			if err := w.store.Write(w.ctx, methodMapperKey, methodMappingBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedWriteMethodMappingToRedis.Error(),
 					zap.String("contract_address", contract.Address.Hex()),
@@ -547,7 +622,7 @@ func (w *SourcifyWriter) processAbiEvents(ctx context.Context, contract *types.C
 			eventKey := types.GetEventStorageKey(w.chainId, event.ID)
 			eventMappingKey := types.GetEventMapperStorageKey(w.chainId, event.ID)
 
-			exists, err := w.redis.Exists(w.ctx, eventKey)
+			exists, err := w.store.Exists(w.ctx, eventKey)
 			if err != nil {
 				zap.L().Error(
 					ErrFailedCheckEventExistenceInRedis.Error(),
@@ -623,7 +698,7 @@ func (w *SourcifyWriter) processAbiEvents(ctx context.Context, contract *types.C
 				return err
 			}
 
-			if err := w.redis.Write(w.ctx, eventKey, eventBytes, 0); err != nil {
+			if err := w.store.Write(w.ctx, eventKey, eventBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedToWriteEventToRedis.Error(),
 					zap.String("contract_address", contract.Address.Hex()),
@@ -644,7 +719,7 @@ func (w *SourcifyWriter) processAbiEvents(ctx context.Context, contract *types.C
 				return err
 			}
 
-			if err := w.redis.Write(w.ctx, eventMappingKey, eventMapperBytes, 0); err != nil {
+			if err := w.store.Write(w.ctx, eventMappingKey, eventMapperBytes, 0); err != nil {
 				zap.L().Error(
 					ErrFailedToWriteEventMappingInfoToRedis.Error(),
 					zap.String("contract_address", contract.Address.Hex()),
@@ -659,3 +734,80 @@ func (w *SourcifyWriter) processAbiEvents(ctx context.Context, contract *types.C
 
 	return nil
 }
+
+func (w *SourcifyWriter) processAbiErrors(ctx context.Context, contract *types.Contract, abiErrors map[string]abi.Error) error {
+	for _, abiError := range abiErrors {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			errorKey := types.GetErrorStorageKey(w.chainId, abiError.ID[:4])
+
+			exists, err := w.store.Exists(w.ctx, errorKey)
+			if err != nil {
+				zap.L().Error(
+					ErrFailedCheckErrorExistenceInRedis.Error(),
+					zap.String("contract_address", contract.Address.Hex()),
+					zap.String("error_name", abiError.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+
+			// Just skip if the error already exists in Redis
+			if exists {
+				continue
+			}
+
+			errorResult := types.NewFullError(abiError)
+
+			// Write into clickhouse but only if clickhouse database is set
+			if w.clickhouseDb != nil {
+				errorExists, err := models.ErrorExists(w.ctx, w.clickhouseDb, errorResult)
+				if err != nil {
+					zap.L().Error(
+						ErrFailedToCheckIfErrorExists.Error(),
+						zap.String("error_name", abiError.Name),
+						zap.Error(err),
+					)
+					continue
+				}
+
+				if !errorExists {
+					if err := models.InsertError(w.ctx, w.clickhouseDb, errorResult); err != nil {
+						zap.L().Error(
+							ErrFailedToInsertError.Error(),
+							zap.Error(err),
+							zap.String("contract_address", contract.Address.Hex()),
+							zap.String("error_name", abiError.Name),
+						)
+						return err
+					}
+				}
+			}
+
+			errorBytes, err := errorResult.MarshalBytes()
+			if err != nil {
+				zap.L().Error(
+					ErrFailedToMarshalError.Error(),
+					zap.String("contract_address", contract.Address.Hex()),
+					zap.String("error_name", abiError.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+
+			if err := w.store.Write(w.ctx, errorKey, errorBytes, 0); err != nil {
+				zap.L().Error(
+					ErrFailedToWriteErrorToRedis.Error(),
+					zap.String("contract_address", contract.Address.Hex()),
+					zap.String("error_name", abiError.Name),
+					zap.Error(err),
+				)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
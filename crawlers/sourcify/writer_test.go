@@ -0,0 +1,80 @@
+package sourcify
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/sourcify-go"
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/types"
+)
+
+func TestSourcifyWriter_Verify(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainID := big.NewInt(56)
+	address := common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/contract/56/"+address.Hex() || r.URL.Query().Get("fields") != "runtimeBytecode" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// PUSH32 holding the immutable value, zeroed in the recompiled bytecode.
+		w.Write([]byte(`{"runtimeBytecode": {
+			"recompiledBytecode": "0x7f0000000000000000000000000000000000000000000000000000000000000000",
+			"immutableReferences": {"3": [{"start": 1, "length": 32}]}
+		}}`))
+	}))
+	defer server.Close()
+
+	// Runtime bytecode is set on every contract, eth client is never reached.
+	writer := NewSourcifyWriter(
+		WithCtx(context.TODO()),
+		WithSourcify(sourcify.NewClient(sourcify.WithBaseURL(server.URL))),
+		WithEthClient(&clients.EthClient{}),
+	)
+	tAssert.NotNil(writer.verifier)
+
+	cases := []struct {
+		name                  string
+		runtime               []byte
+		expectedProcessStatus int8
+	}{
+		{
+			name:    "match",
+			runtime: common.FromHex("0x7f" + common.Bytes2Hex(common.LeftPadBytes([]byte{0x42}, 32))),
+		},
+		{
+			name:                  "mismatch",
+			runtime:               common.FromHex("0x6080604052600080fd"),
+			expectedProcessStatus: helpers.CONTRACT_PROCESS_STATUS_MISMATCH,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tAssert := assert.New(t)
+
+			contract := &types.Contract{
+				Address:            address,
+				RuntimeBytecode:    tc.runtime,
+				VerificationType:   types.ContractVerificationTypeSourcify,
+				VerificationStatus: "perfect",
+			}
+
+			writer.verify(chainID, contract)
+
+			// Status reported by Sourcify is kept either way.
+			tAssert.Equal("perfect", contract.VerificationStatus)
+			tAssert.Equal(tc.expectedProcessStatus, contract.ProcessStatus)
+		})
+	}
+}
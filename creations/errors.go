@@ -0,0 +1,11 @@
+package creations
+
+import "errors"
+
+var (
+	// ErrNoCode is returned when there is no code deployed at the address.
+	ErrNoCode = errors.New("no code at address")
+
+	// ErrCreationNotFound is returned when the creation transaction could not be found in the creation block.
+	ErrCreationNotFound = errors.New("contract creation transaction not found")
+)
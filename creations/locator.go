@@ -0,0 +1,320 @@
+// Package creations locates the transaction that created the contract. The creation block is found by
+// binary searching eth_getCode over block numbers and the creating transaction is then looked up in the
+// block receipts (contracts deployed by the EOA) or the block call traces (contracts deployed by factories).
+// BitQuery can be used as an optional accelerator, every lookup works against the node alone.
+package creations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/scanners"
+	"go.uber.org/zap"
+)
+
+// bitqueryNetworks maps chain IDs onto the BitQuery network names.
+var bitqueryNetworks = map[int64]string{
+	1:  "ethereum",
+	56: "bsc",
+}
+
+// Creation represents the contract creation.
+type Creation struct {
+	// Address represents the address of the created contract.
+	Address common.Address `json:"address"`
+
+	// BlockNumber represents the number of the block where the contract was created.
+	BlockNumber *big.Int `json:"block_number"`
+
+	// BlockHash represents the hash of the block where the contract was created.
+	BlockHash common.Hash `json:"block_hash"`
+
+	// TransactionHash represents the hash of the transaction that created the contract.
+	TransactionHash common.Hash `json:"transaction_hash"`
+
+	// Creator represents the account, or the factory contract, that deployed the contract.
+	Creator common.Address `json:"creator"`
+
+	// Type represents the creation opcode, CREATE or CREATE2.
+	Type string `json:"type"`
+
+	// Bytecode represents the creation (init) code, constructor arguments included. It is known only
+	// when the creation was found through the receipts or the call traces.
+	Bytecode []byte `json:"bytecode,omitempty"`
+}
+
+// Locator locates contract creations using the node, optionally accelerated with BitQuery.
+type Locator struct {
+	ctx       context.Context
+	ethClient *clients.EthClient
+	bitquery  *scanners.BitQueryProvider
+}
+
+// Option defines a function type that applies configurations to a Locator.
+type Option func(*Locator)
+
+func WithEthClient(client *clients.EthClient) Option {
+	return func(l *Locator) {
+		l.ethClient = client
+	}
+}
+
+// WithBitQuery sets the BitQuery provider which is asked first, before falling back to the node.
+func WithBitQuery(bq *scanners.BitQueryProvider) Option {
+	return func(l *Locator) {
+		l.bitquery = bq
+	}
+}
+
+func NewLocator(ctx context.Context, opts ...Option) (*Locator, error) {
+	locator := &Locator{ctx: ctx}
+
+	for _, opt := range opts {
+		opt(locator)
+	}
+
+	if locator.ethClient == nil {
+		return nil, errors.New("eth client is required")
+	}
+
+	return locator, nil
+}
+
+// Locate finds the creation of the contract at the address. The node lookup queries historical state,
+// which means the node has to be an archive node exposing the debug namespace.
+func (l *Locator) Locate(chainId *big.Int, addr common.Address) (*Creation, error) {
+	if l.bitquery != nil {
+		creation, err := l.locateWithBitQuery(chainId, addr)
+		if err == nil {
+			return creation, nil
+		}
+
+		zap.L().Debug(
+			"failed to locate contract creation with bitquery, falling back to the node",
+			zap.String("address", addr.Hex()),
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Error(err),
+		)
+	}
+
+	blockNumber, err := l.FindCreationBlock(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.locateInBlock(chainId, addr, new(big.Int).SetUint64(blockNumber))
+}
+
+// FindCreationBlock binary searches eth_getCode over block numbers for the first block the contract has code at.
+func (l *Locator) FindCreationBlock(addr common.Address) (uint64, error) {
+	latest, err := helpers.GetBlockNumber(l.ctx, l.ethClient)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block number: %w", err)
+	}
+
+	return searchCreationBlock(latest, func(blockNumber uint64) (bool, error) {
+		code, err := helpers.GetBytecode(l.ctx, l.ethClient, addr, new(big.Int).SetUint64(blockNumber))
+		if err != nil {
+			return false, fmt.Errorf("failed to get bytecode at block %d: %w", blockNumber, err)
+		}
+
+		return len(code) > 0, nil
+	})
+}
+
+// locateInBlock looks up the creating transaction within the creation block. Receipts are checked first
+// as they cover contracts deployed directly by the EOA, call traces cover factory CREATE and CREATE2.
+func (l *Locator) locateInBlock(chainId *big.Int, addr common.Address, blockNumber *big.Int) (*Creation, error) {
+	block, err := helpers.GetBlockByNumber(l.ctx, l.ethClient, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", blockNumber, err)
+	}
+
+	signer := types.LatestSignerForChainID(chainId)
+
+	for _, tx := range block.Transactions() {
+		if tx.To() != nil {
+			continue
+		}
+
+		receipt, err := helpers.GetReceiptByHash(l.ctx, l.ethClient, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt %s: %w", tx.Hash().Hex(), err)
+		}
+
+		if receipt.ContractAddress != addr {
+			continue
+		}
+
+		creator, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover sender of %s: %w", tx.Hash().Hex(), err)
+		}
+
+		return &Creation{
+			Address:         addr,
+			BlockNumber:     blockNumber,
+			BlockHash:       block.Hash(),
+			TransactionHash: tx.Hash(),
+			Creator:         creator,
+			Type:            "CREATE",
+			Bytecode:        tx.Data(),
+		}, nil
+	}
+
+	traces, err := helpers.TraceBlockByNumber(l.ctx, l.ethClient, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace block %s: %w", blockNumber, err)
+	}
+
+	for i, trace := range traces {
+		if trace.Result == nil {
+			continue
+		}
+
+		frame := findCreateFrame(trace.Result, addr)
+		if frame == nil {
+			continue
+		}
+
+		// Older nodes do not report transaction hashes within the block trace, fall back to the block order.
+		txHash := trace.TxHash
+		if txHash == (common.Hash{}) && i < len(block.Transactions()) {
+			txHash = block.Transactions()[i].Hash()
+		}
+
+		return &Creation{
+			Address:         addr,
+			BlockNumber:     blockNumber,
+			BlockHash:       block.Hash(),
+			TransactionHash: txHash,
+			Creator:         frame.From,
+			Type:            strings.ToUpper(frame.Type),
+			Bytecode:        frame.Input,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s in block %s", ErrCreationNotFound, addr.Hex(), blockNumber)
+}
+
+// locateWithBitQuery asks BitQuery for the creation transaction and completes the creation from its receipt.
+func (l *Locator) locateWithBitQuery(chainId *big.Int, addr common.Address) (*Creation, error) {
+	network, ok := bitqueryNetworks[chainId.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("bitquery does not support chain %s", chainId)
+	}
+
+	queryData := map[string]string{
+		"query": fmt.Sprintf(`{
+			smartContractCreation: ethereum(network: %s) {
+			  smartContractCalls(
+				smartContractAddress: {is: "%s"}
+				smartContractMethod: {is: "Contract Creation"}
+			  ) {
+				transaction {
+				  hash
+				}
+				block {
+				  height
+				}
+			  }
+			}
+		  }`, network, addr.Hex()),
+	}
+
+	info, err := l.bitquery.GetContractCreationInfo(queryData)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := info.Data.SmartContractCreation.SmartContractCalls
+	if len(calls) == 0 {
+		return nil, ErrCreationNotFound
+	}
+
+	txHash := common.HexToHash(calls[0].Transaction.Hash)
+
+	receipt, err := helpers.GetReceiptByHash(l.ctx, l.ethClient, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt %s: %w", txHash.Hex(), err)
+	}
+
+	// Contracts deployed by factories are not reported in the receipt, the block lookup resolves them from the call traces.
+	if receipt.ContractAddress != addr {
+		return l.locateInBlock(chainId, addr, receipt.BlockNumber)
+	}
+
+	tx, _, err := helpers.GetTransactionByHash(l.ctx, l.ethClient, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", txHash.Hex(), err)
+	}
+
+	creator, err := types.Sender(types.LatestSignerForChainID(chainId), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender of %s: %w", txHash.Hex(), err)
+	}
+
+	return &Creation{
+		Address:         addr,
+		BlockNumber:     receipt.BlockNumber,
+		BlockHash:       receipt.BlockHash,
+		TransactionHash: txHash,
+		Creator:         creator,
+		Type:            "CREATE",
+		Bytecode:        tx.Data(),
+	}, nil
+}
+
+// searchCreationBlock returns the first block number, up to the latest one, hasCode reports the code at.
+// Code presence is expected to be monotonic, i.e. once deployed the contract keeps its code.
+func searchCreationBlock(latest uint64, hasCode func(blockNumber uint64) (bool, error)) (uint64, error) {
+	ok, err := hasCode(latest)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return 0, ErrNoCode
+	}
+
+	low, high := uint64(0), latest
+	for low < high {
+		mid := low + (high-low)/2
+
+		ok, err := hasCode(mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	return low, nil
+}
+
+// findCreateFrame walks the call tree depth first and returns the CREATE or CREATE2 frame creating the address.
+func findCreateFrame(frame *helpers.CallFrame, addr common.Address) *helpers.CallFrame {
+	frameType := strings.ToUpper(frame.Type)
+	if (frameType == "CREATE" || frameType == "CREATE2") && frame.To != nil && *frame.To == addr && frame.Error == "" {
+		return frame
+	}
+
+	for i := range frame.Calls {
+		if found := findCreateFrame(&frame.Calls[i], addr); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
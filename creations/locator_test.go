@@ -0,0 +1,76 @@
+package creations
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/helpers"
+)
+
+func TestLocator_SearchCreationBlock(t *testing.T) {
+	tAssert := assert.New(t)
+
+	calls := 0
+	blockNumber, err := searchCreationBlock(1_000_000, func(blockNumber uint64) (bool, error) {
+		calls++
+		return blockNumber >= 123_456, nil
+	})
+	tAssert.NoError(err)
+	tAssert.Equal(uint64(123_456), blockNumber)
+	tAssert.LessOrEqual(calls, 22)
+
+	blockNumber, err = searchCreationBlock(100, func(blockNumber uint64) (bool, error) {
+		return true, nil
+	})
+	tAssert.NoError(err)
+	tAssert.Equal(uint64(0), blockNumber)
+
+	_, err = searchCreationBlock(100, func(blockNumber uint64) (bool, error) {
+		return false, nil
+	})
+	tAssert.ErrorIs(err, ErrNoCode)
+
+	rpcErr := errors.New("rpc error")
+	_, err = searchCreationBlock(100, func(blockNumber uint64) (bool, error) {
+		if blockNumber < 100 {
+			return false, rpcErr
+		}
+		return true, nil
+	})
+	tAssert.ErrorIs(err, rpcErr)
+}
+
+func TestLocator_FindCreateFrame(t *testing.T) {
+	tAssert := assert.New(t)
+
+	factory := common.HexToAddress("0xfafafafafafafafafafafafafafafafafafafafa")
+	created := common.HexToAddress("0xc0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0")
+	reverted := common.HexToAddress("0xdededededededededededededededededededede")
+
+	root := &helpers.CallFrame{
+		Type: "CALL",
+		To:   &factory,
+		Calls: []helpers.CallFrame{
+			{Type: "CREATE", From: factory, To: &reverted, Error: "execution reverted"},
+			{
+				Type: "DELEGATECALL",
+				To:   &factory,
+				Calls: []helpers.CallFrame{
+					{Type: "CREATE2", From: factory, To: &created, Input: common.FromHex("0x6080")},
+				},
+			},
+		},
+	}
+
+	frame := findCreateFrame(root, created)
+	if tAssert.NotNil(frame) {
+		tAssert.Equal("CREATE2", frame.Type)
+		tAssert.Equal(factory, frame.From)
+		tAssert.Equal(common.FromHex("0x6080"), []byte(frame.Input))
+	}
+
+	tAssert.Nil(findCreateFrame(root, reverted))
+	tAssert.Nil(findCreateFrame(root, factory))
+}
@@ -3,6 +3,8 @@ package db
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/dgraph-io/badger/v4"
 )
@@ -12,6 +14,8 @@ type BadgerDB struct {
 	ctx    context.Context // Associated context
 	dbPath string          // Path where Badger DB is located
 	db     *badger.DB      // The Badger DB instance
+
+	updateMu sync.Mutex // Serializes read-modify-write updates, which would otherwise keep conflicting.
 }
 
 // Option is a function that applies a configuration option to a BadgerDB.
@@ -114,6 +118,101 @@ func (d *BadgerDB) Write(key string, value []byte) error {
 	return err
 }
 
+// WriteWithTTL sets the value for a given key in the BadgerDB, expiring it after the ttl.
+// A ttl of zero keeps the key forever, the same as Write.
+//
+// Example usage:
+//
+//	err := db.WriteWithTTL("myKey", []byte("myValue"), time.Hour)
+func (d *BadgerDB) WriteWithTTL(key string, value []byte, ttl time.Duration) error {
+	return d.db.Update(func(txn *badger.Txn) error {
+		entry := badger.NewEntry([]byte(key), value)
+		if ttl > 0 {
+			entry = entry.WithTTL(ttl)
+		}
+		return txn.SetEntry(entry)
+	})
+}
+
+// maxUpdateAttempts is the number of times Update retries the transaction conflicting with concurrent writers.
+const maxUpdateAttempts = 10
+
+// Update atomically replaces the value of the key with the one returned by fn, which receives the current
+// value or nil when the key is missing. Updates are serialized and the transaction is retried when it
+// conflicts with the concurrent plain writes. Returning nil value from fn leaves the key untouched.
+//
+// Example usage:
+//
+//	err := db.Update("myKey", func(current []byte) ([]byte, error) {
+//	    return append(current, "myValue"...), nil
+//	})
+func (d *BadgerDB) Update(key string, fn func(current []byte) ([]byte, error)) error {
+	d.updateMu.Lock()
+	defer d.updateMu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		err = d.db.Update(func(txn *badger.Txn) error {
+			var current []byte
+
+			item, err := txn.Get([]byte(key))
+			if err == nil {
+				if current, err = item.ValueCopy(nil); err != nil {
+					return err
+				}
+			} else if err != badger.ErrKeyNotFound {
+				return err
+			}
+
+			value, err := fn(current)
+			if err != nil || value == nil {
+				return err
+			}
+
+			return txn.Set([]byte(key), value)
+		})
+		if err != badger.ErrConflict {
+			return err
+		}
+	}
+
+	return err
+}
+
+// Iterate calls fn with every key starting with the prefix and its value, in the ascending key order.
+// The iteration stops at the first error returned by fn, which is then returned.
+//
+// Example usage:
+//
+//	err := db.Iterate("myPrefix:", func(key string, value []byte) error {
+//	    fmt.Println(key, string(value))
+//	    return nil
+//	})
+func (d *BadgerDB) Iterate(prefix string, fn func(key string, value []byte) error) error {
+	return d.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			if err := fn(string(item.KeyCopy(nil)), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // Exists checks if a key exists in the BadgerDB.
 //
 // Returns a boolean indicating if the key exists and any error encountered.
@@ -0,0 +1,8 @@
+package db
+
+import "errors"
+
+var (
+	// ErrUnsupportedValue is returned when the value written into the BadgerStore is neither bytes nor a string
+	ErrUnsupportedValue = errors.New("unsupported value type")
+)
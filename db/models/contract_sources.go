@@ -0,0 +1,123 @@
+package models
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/types"
+)
+
+func CreateContractSourcesTable(ctx context.Context, client *db.ClickHouse) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS contract_sources (
+			uuid UUID,
+			chain_id Int64,
+			contract_address String,
+			format String,
+			language String,
+			sources Map(String, String),
+			settings String,
+			remappings Array(String),
+			timestamp DateTime DEFAULT now()
+		) engine=MergeTree() order by (chain_id, contract_address, timestamp)
+	`
+
+	if err := client.DB().Exec(ctx, query); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func InsertContractSource(ctx context.Context, client *db.ClickHouse, source *types.ContractSource) error {
+	query := `
+		INSERT INTO contract_sources (
+			uuid,
+			chain_id,
+			contract_address,
+			format,
+			language,
+			sources,
+			settings,
+			remappings
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	remappings := source.Remappings
+	if remappings == nil {
+		remappings = []string{}
+	}
+
+	err := client.DB().Exec(ctx, query,
+		source.UUID.String(),
+		source.ChainID.Int64(),
+		source.Address.Hex(),
+		string(source.Format),
+		string(source.Language),
+		source.Sources,
+		source.Settings,
+		remappings,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetContractSource returns the most recently stored source tree of the contract.
+func GetContractSource(ctx context.Context, client *db.ClickHouse, chainId *big.Int, addr common.Address) (*types.ContractSource, error) {
+	query := `
+		SELECT
+			uuid,
+			chain_id,
+			contract_address,
+			format,
+			language,
+			sources,
+			settings,
+			remappings
+		FROM contract_sources WHERE contract_address = ? AND chain_id = ?
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	source := &types.ContractSource{}
+
+	var rawChainId int64
+	var address, format, language string
+
+	if err := client.DB().QueryRow(ctx, query, addr.Hex(), chainId.Int64()).Scan(
+		&source.UUID,
+		&rawChainId,
+		&address,
+		&format,
+		&language,
+		&source.Sources,
+		&source.Settings,
+		&source.Remappings,
+	); err != nil {
+		return nil, err
+	}
+
+	source.ChainID = big.NewInt(rawChainId)
+	source.Address = common.HexToAddress(address)
+	source.Format = types.SourceFormat(format)
+	source.Language = types.ContractLanguage(language)
+
+	return source, nil
+}
+
+// ContractSourceExists checks whether the source tree of the contract is already stored.
+func ContractSourceExists(ctx context.Context, client *db.ClickHouse, chainId *big.Int, addr common.Address) (bool, error) {
+	query := `SELECT COUNT(*) FROM contract_sources WHERE contract_address = ? AND chain_id = ?`
+
+	var count uint64
+	if err := client.DB().QueryRow(ctx, query, addr.Hex(), chainId.Int64()).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
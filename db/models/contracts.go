@@ -77,6 +77,12 @@ func InsertContract(ctx context.Context, client *db.ClickHouse, contract *types.
 		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	// Contracts are queued for processing unless the verifier already flagged them.
+	processStatus := contract.ProcessStatus
+	if processStatus == helpers.CONTRACT_PROCESS_STATUS_INIT {
+		processStatus = helpers.CONTRACT_PROCESS_STATUS_PENDING
+	}
+
 	err := client.DB().Exec(ctx, query,
 		contract.UUID.String(),
 		contract.ChainID.Int64(),
@@ -100,7 +106,7 @@ func InsertContract(ctx context.Context, client *db.ClickHouse, contract *types.
 		contract.SourceUrls,
 		contract.VerificationType,
 		contract.VerificationStatus,
-		helpers.CONTRACT_PROCESS_STATUS_PENDING,
+		processStatus,
 	)
 	if err != nil {
 		return err
@@ -109,44 +115,76 @@ func InsertContract(ctx context.Context, client *db.ClickHouse, contract *types.
 	return nil
 }
 
+const selectContractsQuery = `
+	SELECT
+		uuid,
+		chain_id,
+		block_hash,
+		transaction_hash,
+		contract_address,
+		name,
+		language,
+		compiler_version,
+		optimization_used,
+		runs,
+		constructor_arguments,
+		evm_version,
+		library,
+		license_type,
+		proxy,
+		source_code,
+		constructor_abi,
+		abi,
+		metadata,
+		source_urls,
+		verification_type,
+		verification_status,
+		process_status
+	FROM contracts
+`
+
 func GetContract(ctx context.Context, client *db.ClickHouse, chainId *big.Int, addr common.Address) (*types.Contract, error) {
-	query := `
-		SELECT 
-			uuid,
-			chain_id,
-			block_hash,
-			transaction_hash,
-			contract_address,
-			name,
-			language,
-			compiler_version,
-			optimization_used,
-			runs,
-			constructor_arguments,
-			evm_version,
-			library,
-			license_type,
-			proxy,
-			source_code,
-			constructor_abi,
-			abi,
-			metadata,
-			source_urls,
-			verification_type,
-			verification_status,
-			process_status
-		FROM contracts WHERE contract_address = ? AND chain_id = ?
-	`
+	row := client.DB().QueryRow(ctx, selectContractsQuery+" WHERE contract_address = ? AND chain_id = ?", addr.Hex(), chainId.Int64())
+
+	return scanContract(row)
+}
 
-	row := client.DB().QueryRow(ctx, query, addr.Hex(), chainId.Int64())
+// GetContractsByAddresses returns the contract for each of the provided addresses in a single query.
+// Addresses without any stored contract are not present in the returned map.
+func GetContractsByAddresses(ctx context.Context, client *db.ClickHouse, chainId *big.Int, addrs []common.Address) (map[common.Address]*types.Contract, error) {
+	toReturn := make(map[common.Address]*types.Contract, len(addrs))
+	if len(addrs) == 0 {
+		return toReturn, nil
+	}
 
+	rows, err := client.DB().Query(ctx,
+		selectContractsQuery+" WHERE contract_address IN ? AND chain_id = ? LIMIT 1 BY contract_address",
+		groupSet(addrs, common.Address.Hex), chainId.Int64(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		contract, err := scanContract(rows)
+		if err != nil {
+			return nil, err
+		}
+		toReturn[contract.Address] = contract
+	}
+
+	return toReturn, rows.Err()
+}
+
+func scanContract(row rowScanner) (*types.Contract, error) {
 	contract := &types.Contract{}
 
 	var rawChainId int64
 	var blockHash, transactionHash, address, language string
 	var verificationType int16
 
-	err := row.Scan(
+	if err := row.Scan(
 		&contract.UUID,
 		&rawChainId,
 		&blockHash,
@@ -170,7 +208,9 @@ func GetContract(ctx context.Context, client *db.ClickHouse, chainId *big.Int, a
 		&verificationType,
 		&contract.VerificationStatus,
 		&contract.ProcessStatus,
-	)
+	); err != nil {
+		return nil, err
+	}
 
 	contract.ChainID = big.NewInt(rawChainId)
 	contract.BlockHash = common.HexToHash(blockHash)
@@ -178,10 +218,6 @@ func GetContract(ctx context.Context, client *db.ClickHouse, chainId *big.Int, a
 	contract.Address = common.HexToAddress(address)
 	contract.VerificationType = types.VerificationType(verificationType)
 
-	if err != nil {
-		return nil, err
-	}
-
 	return contract, nil
 }
 
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/types"
+)
+
+func CreateErrorsTable(ctx context.Context, client *db.ClickHouse) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS errors (
+			uuid UUID,
+			name String,
+			signature String,
+			hex String,
+			bytes Array(UInt8),
+			is_partial bool,
+			arguments Nullable(String),
+			timestamp DateTime DEFAULT now()
+		) engine=MergeTree() order by (uuid, hex, timestamp)
+	`
+
+	if err := client.DB().Exec(ctx, query); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func InsertError(ctx context.Context, client *db.ClickHouse, customError *types.Error) error {
+	query := `
+		INSERT INTO errors (
+			uuid,
+			name,
+			signature,
+			hex,
+			bytes,
+			is_partial,
+			arguments
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	err := client.DB().Exec(ctx, query,
+		customError.UUID.String(),
+		customError.Name,
+		customError.Signature,
+		customError.Hex,
+		customError.Bytes,
+		customError.IsPartial,
+		customError.GetArgumentsAsJSON(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetError returns the custom error matching provided hex encoded selector.
+// Selector may be provided with or without 0x prefix.
+func GetError(ctx context.Context, client *db.ClickHouse, selector string) (*types.Error, error) {
+	query := `
+		SELECT
+			uuid,
+			name,
+			signature,
+			hex,
+			bytes,
+			is_partial,
+			arguments
+		FROM errors
+		WHERE hex = ?
+		LIMIT 1
+	`
+
+	var customError types.Error
+	var arguments *string
+
+	if err := client.DB().QueryRow(ctx, query, strings.TrimPrefix(selector, "0x")).Scan(
+		&customError.UUID,
+		&customError.Name,
+		&customError.Signature,
+		&customError.Hex,
+		&customError.Bytes,
+		&customError.IsPartial,
+		&arguments,
+	); err != nil {
+		return nil, err
+	}
+
+	if arguments != nil {
+		if err := json.Unmarshal([]byte(*arguments), &customError.Arguments); err != nil {
+			return nil, err
+		}
+	}
+
+	return &customError, nil
+}
+
+func ErrorExists(ctx context.Context, client *db.ClickHouse, customError *types.Error) (bool, error) {
+	query := `SELECT COUNT(*) FROM errors WHERE hex = ? AND signature = ?`
+
+	var count uint64
+	if err := client.DB().QueryRow(ctx, query, customError.Hex, customError.Signature).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func DeleteErrorById(ctx context.Context, client *db.ClickHouse, id *uuid.UUID) error {
+	query := `DELETE FROM errors WHERE uuid = ?`
+
+	if err := client.DB().Exec(ctx, query, id.String()); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -66,37 +67,77 @@ func InsertEvent(ctx context.Context, client *db.ClickHouse, method *types.Event
 	return nil
 }
 
+const selectEventsQuery = `
+	SELECT
+		uuid,
+		name,
+		raw_name,
+		signature,
+		hash,
+		is_anonymous,
+		is_partial,
+		arguments
+	FROM events
+`
+
 func GetEvent(ctx context.Context, client *db.ClickHouse, chainId *big.Int, hash common.Hash) (*types.Event, error) {
-	query := `
-		SELECT
-			uuid,
-			name,
-			raw_name,
-			signature,
-			hash,
-			is_anonymous,
-			is_partial,
-			arguments
-		FROM events
-		WHERE hash = ?
-		LIMIT 1
-	`
+	row := client.DB().QueryRow(ctx, selectEventsQuery+" WHERE hash = ? LIMIT 1", hash.Hex())
+
+	return scanEvent(row)
+}
 
-	var method types.Event
-	if err := client.DB().QueryRow(ctx, query, hash.Hex()).Scan(
-		&method.UUID,
-		&method.Name,
-		&method.RawName,
-		&method.Signature,
-		&method.Hash,
-		&method.IsAnonymous,
-		&method.IsPartial,
-		&method.Arguments,
+// GetEventsByHashes returns the event for each of the provided hashes in a single query.
+// Hashes without any stored event are not present in the returned map.
+func GetEventsByHashes(ctx context.Context, client *db.ClickHouse, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	toReturn := make(map[common.Hash]*types.Event, len(hashes))
+	if len(hashes) == 0 {
+		return toReturn, nil
+	}
+
+	rows, err := client.DB().Query(ctx, selectEventsQuery+" WHERE hash IN ? LIMIT 1 BY hash", groupSet(hashes, common.Hash.Hex))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		toReturn[event.Hash] = event
+	}
+
+	return toReturn, rows.Err()
+}
+
+func scanEvent(row rowScanner) (*types.Event, error) {
+	var event types.Event
+	var eventHash string
+	var arguments *string
+
+	if err := row.Scan(
+		&event.UUID,
+		&event.Name,
+		&event.RawName,
+		&event.Signature,
+		&eventHash,
+		&event.IsAnonymous,
+		&event.IsPartial,
+		&arguments,
 	); err != nil {
 		return nil, err
 	}
 
-	return &method, nil
+	event.Hash = common.HexToHash(eventHash)
+
+	if arguments != nil {
+		if err := json.Unmarshal([]byte(*arguments), &event.Arguments); err != nil {
+			return nil, err
+		}
+	}
+
+	return &event, nil
 }
 
 func DeleteEventById(ctx context.Context, client *db.ClickHouse, id *uuid.UUID) error {
@@ -2,7 +2,12 @@ package models
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
 
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/google/uuid"
 	"github.com/txpull/unpack/db"
 	"github.com/txpull/unpack/types"
@@ -24,6 +29,7 @@ func CreateMethodsTable(ctx context.Context, client *db.ClickHouse) error {
 			is_constant bool,
 			is_payable bool,
 			is_partial bool,
+			fourbyte_id Int64 DEFAULT 0,
 			arguments Nullable(String),
 			returns Nullable(String),
 			state_mutability Nullable(String),
@@ -36,30 +42,45 @@ func CreateMethodsTable(ctx context.Context, client *db.ClickHouse) error {
 		return err
 	}
 
+	// Tables created before 4byte ids were tracked are missing the column.
+	if err := client.DB().Exec(ctx, `ALTER TABLE methods ADD COLUMN IF NOT EXISTS fourbyte_id Int64 DEFAULT 0 AFTER is_partial`); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+const insertMethodQuery = `
+	INSERT INTO methods (
+		uuid,
+		name,
+		raw_name,
+		signature,
+		hex,
+		bytes,
+		is_constant,
+		is_payable,
+		is_partial,
+		fourbyte_id,
+		arguments,
+		returns,
+		state_mutability,
+		type
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
 func InsertMethod(ctx context.Context, client *db.ClickHouse, method *types.Method) error {
-	query := `
-		INSERT INTO methods (
-			uuid,
-			name,
-			raw_name,
-			signature,
-			hex,
-			bytes,
-			is_constant,
-			is_payable,
-			is_partial,
-			arguments,
-			returns,
-			state_mutability,
-			type
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	if err := client.DB().Exec(ctx, insertMethodQuery, insertMethodArgs(method)...); err != nil {
+		return err
+	}
+
+	return nil
+}
 
-	err := client.DB().Exec(ctx, query,
-		uuid.New().String(),
+// insertMethodArgs returns the values bound to insertMethodQuery, in the order of its columns.
+func insertMethodArgs(method *types.Method) []interface{} {
+	return []interface{}{
+		method.UUID.String(),
 		method.Name,
 		method.RawName,
 		method.Signature,
@@ -68,40 +89,120 @@ func InsertMethod(ctx context.Context, client *db.ClickHouse, method *types.Meth
 		method.IsConstant,
 		method.IsPayable,
 		method.IsPartial,
+		method.FourByteID,
 		method.GetArgumentsAsJSON(),
 		method.GetReturnsAsJSON(),
 		method.StateMutability,
 		method.Type,
-	)
+	}
+}
+
+const selectMethodsQuery = `
+	SELECT
+		uuid,
+		name,
+		raw_name,
+		signature,
+		hex,
+		bytes,
+		is_constant,
+		is_payable,
+		is_partial,
+		fourbyte_id,
+		arguments,
+		returns,
+		state_mutability,
+		type
+	FROM methods
+`
+
+// GetMethod returns the method matching provided hex encoded selector.
+// Selector may be provided with or without 0x prefix.
+func GetMethod(ctx context.Context, client *db.ClickHouse, selector string) (*types.Method, error) {
+	row := client.DB().QueryRow(ctx, selectMethodsQuery+" WHERE hex = ? LIMIT 1", strings.TrimPrefix(selector, "0x"))
+
+	return scanMethod(row)
+}
+
+// GetMethods returns all of the methods sharing provided hex encoded selector.
+// Selector may be provided with or without 0x prefix.
+func GetMethods(ctx context.Context, client *db.ClickHouse, selector string) (types.Methods, error) {
+	rows, err := client.DB().Query(ctx, selectMethodsQuery+" WHERE hex = ?", strings.TrimPrefix(selector, "0x"))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return nil
+	var methods types.Methods
+	for rows.Next() {
+		method, err := scanMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if !methods.Contains(method.Signature) {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods, rows.Err()
 }
 
-func GetMethod(ctx context.Context, client *db.ClickHouse, signature string) (*types.Method, error) {
-	query := `
-		SELECT
-			uuid,
-			name,
-			raw_name,
-			signature,
-			hex,
-			bytes,
-			is_constant,
-			is_payable,
-			is_partial,
-			arguments,
-			returns,
-			state_mutability,
-			type
-		FROM methods
-		WHERE signature = ?
-	`
+// GetMethodsBySelectors returns the method for each of the provided hex encoded selectors in a single query.
+// Selectors may be provided with or without 0x prefix, the returned map is keyed by the selector as provided.
+// Selectors without any stored method are not present in the returned map.
+func GetMethodsBySelectors(ctx context.Context, client *db.ClickHouse, selectors []string) (map[string]*types.Method, error) {
+	toReturn := make(map[string]*types.Method, len(selectors))
+	if len(selectors) == 0 {
+		return toReturn, nil
+	}
+
+	keys := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		keys[strings.TrimPrefix(selector, "0x")] = selector
+	}
+
+	rows, err := client.DB().Query(ctx, selectMethodsQuery+" WHERE hex IN ? LIMIT 1 BY hex", groupSet(selectors, func(selector string) string {
+		return strings.TrimPrefix(selector, "0x")
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		method, err := scanMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if selector, ok := keys[method.Hex]; ok {
+			toReturn[selector] = method
+		}
+	}
+
+	return toReturn, rows.Err()
+}
+
+// groupSet converts the values into the group set bound as `IN (...)` list of the query.
+func groupSet[T any](values []T, convert func(T) string) clickhouse.GroupSet {
+	toReturn := clickhouse.GroupSet{Value: make([]any, 0, len(values))}
+	for _, value := range values {
+		toReturn.Value = append(toReturn.Value, convert(value))
+	}
+	return toReturn
+}
+
+// rowScanner is implemented by both the single row and the rows cursor.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
+func scanMethod(row rowScanner) (*types.Method, error) {
 	var method types.Method
-	if err := client.DB().QueryRow(ctx, query, signature).Scan(
+	var arguments, returns, stateMutability, methodType *string
+
+	if err := row.Scan(
 		&method.UUID,
 		&method.Name,
 		&method.RawName,
@@ -111,22 +212,47 @@ func GetMethod(ctx context.Context, client *db.ClickHouse, signature string) (*t
 		&method.IsConstant,
 		&method.IsPayable,
 		&method.IsPartial,
-		&method.Arguments,
-		&method.Returns,
-		&method.StateMutability,
-		&method.Type,
+		&method.FourByteID,
+		&arguments,
+		&returns,
+		&stateMutability,
+		&methodType,
 	); err != nil {
 		return nil, err
 	}
 
+	if arguments != nil {
+		if err := json.Unmarshal([]byte(*arguments), &method.Arguments); err != nil {
+			return nil, err
+		}
+	}
+
+	if returns != nil {
+		if err := json.Unmarshal([]byte(*returns), &method.Returns); err != nil {
+			return nil, err
+		}
+	}
+
+	if stateMutability != nil {
+		method.StateMutability = *stateMutability
+	}
+
+	if methodType != nil {
+		if t, err := strconv.Atoi(*methodType); err == nil {
+			method.Type = abi.FunctionType(t)
+		}
+	}
+
 	return &method, nil
 }
 
+// MethodExists checks whether the method with the same selector and signature is already stored.
+// Colliding signatures sharing the selector are stored as separate methods.
 func MethodExists(ctx context.Context, client *db.ClickHouse, method *types.Method) (bool, error) {
-	query := `SELECT COUNT(*) FROM methods WHERE hex = ?`
+	query := `SELECT COUNT(*) FROM methods WHERE hex = ? AND signature = ?`
 
 	var count uint64
-	if err := client.DB().QueryRow(ctx, query, method.Hex).Scan(&count); err != nil {
+	if err := client.DB().QueryRow(ctx, query, method.Hex, method.Signature).Scan(&count); err != nil {
 		return false, err
 	}
 
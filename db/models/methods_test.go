@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/types"
+)
+
+func TestMethods_InsertMethodQuery(t *testing.T) {
+	tAssert := assert.New(t)
+
+	method, err := types.NewFourByteMethod("0xa9059cbb", "transfer(address,uint256)")
+	tAssert.NoError(err)
+	method.FourByteID = 145
+
+	parts := strings.SplitN(insertMethodQuery, "VALUES", 2)
+	tAssert.Len(parts, 2)
+
+	columnsList := parts[0][strings.Index(parts[0], "(")+1 : strings.LastIndex(parts[0], ")")]
+	columns := strings.Split(columnsList, ",")
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(column)
+	}
+
+	args := insertMethodArgs(method)
+
+	tAssert.Len(columns, 14)
+	tAssert.Equal(len(columns), strings.Count(parts[1], "?"))
+	tAssert.Len(args, len(columns))
+
+	tAssert.Equal("uuid", columns[0])
+	tAssert.Equal(method.UUID.String(), args[0])
+
+	tAssert.Equal("fourbyte_id", columns[9])
+	tAssert.Equal(int64(145), args[9])
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/types"
+)
+
+func CreateProxiesTable(ctx context.Context, client *db.ClickHouse) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS proxies (
+			uuid UUID,
+			chain_id Int64,
+			proxy_address String,
+			implementation_address String,
+			beacon_address Nullable(String),
+			proxy_type String,
+			timestamp DateTime DEFAULT now()
+		) engine=MergeTree() order by (chain_id, proxy_address, timestamp)
+	`
+
+	if err := client.DB().Exec(ctx, query); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func InsertProxy(ctx context.Context, client *db.ClickHouse, proxy *types.Proxy) error {
+	query := `
+		INSERT INTO proxies (
+			uuid,
+			chain_id,
+			proxy_address,
+			implementation_address,
+			beacon_address,
+			proxy_type
+		) VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	var beacon *string
+	if proxy.Beacon != nil {
+		address := proxy.Beacon.Hex()
+		beacon = &address
+	}
+
+	err := client.DB().Exec(ctx, query,
+		proxy.UUID.String(),
+		proxy.ChainID.Int64(),
+		proxy.Address.Hex(),
+		proxy.Implementation.Hex(),
+		beacon,
+		string(proxy.Type),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetProxy returns the most recently stored implementation link of the proxy contract.
+// Proxies can be upgraded and therefore every observed implementation is stored as the new row.
+func GetProxy(ctx context.Context, client *db.ClickHouse, chainId *big.Int, addr common.Address) (*types.Proxy, error) {
+	query := `
+		SELECT
+			uuid,
+			chain_id,
+			proxy_address,
+			implementation_address,
+			beacon_address,
+			proxy_type
+		FROM proxies WHERE proxy_address = ? AND chain_id = ?
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	proxy := &types.Proxy{}
+
+	var rawChainId int64
+	var address, implementation, proxyType string
+	var beacon *string
+
+	if err := client.DB().QueryRow(ctx, query, addr.Hex(), chainId.Int64()).Scan(
+		&proxy.UUID,
+		&rawChainId,
+		&address,
+		&implementation,
+		&beacon,
+		&proxyType,
+	); err != nil {
+		return nil, err
+	}
+
+	proxy.ChainID = big.NewInt(rawChainId)
+	proxy.Address = common.HexToAddress(address)
+	proxy.Implementation = common.HexToAddress(implementation)
+	proxy.Type = types.ProxyType(proxyType)
+
+	if beacon != nil {
+		beaconAddress := common.HexToAddress(*beacon)
+		proxy.Beacon = &beaconAddress
+	}
+
+	return proxy, nil
+}
+
+// ProxyExists checks whether the exact proxy to implementation link is already stored.
+func ProxyExists(ctx context.Context, client *db.ClickHouse, proxy *types.Proxy) (bool, error) {
+	query := `SELECT COUNT(*) FROM proxies WHERE proxy_address = ? AND implementation_address = ? AND chain_id = ?`
+
+	var count uint64
+	if err := client.DB().QueryRow(ctx, query, proxy.Address.Hex(), proxy.Implementation.Hex(), proxy.ChainID.Int64()).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
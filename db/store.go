@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Store is the key-value storage the crawlers persist contracts and signatures into. It is satisfied by
+// clients.Redis and, for the fully embedded deployments without any external services, by BadgerStore.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Write(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+
+	// Update atomically replaces the value of the key with the one returned by fn. The fn receives the
+	// current value, or nil when the key is missing, and may be called more than once when concurrent
+	// writers collide. Returning nil value leaves the key untouched.
+	Update(ctx context.Context, key string, fn func(current []byte) ([]byte, error)) error
+}
+
+// BadgerStore adapts BadgerDB to the Store interface. Missing keys are reported with badger.ErrKeyNotFound.
+type BadgerStore struct {
+	db *BadgerDB
+}
+
+// NewBadgerStore creates a new Store writing into the provided BadgerDB.
+//
+// Example usage:
+//
+//	bdb, err := NewBadgerDB(WithDbPath("/tmp/mydb"))
+//	store := NewBadgerStore(bdb)
+func NewBadgerStore(db *BadgerDB) *BadgerStore {
+	return &BadgerStore{db: db}
+}
+
+// Get retrieves the value for a given key.
+func (s *BadgerStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.db.Get(key)
+}
+
+// Exists checks if a key exists.
+func (s *BadgerStore) Exists(ctx context.Context, key string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return s.db.Exists(key)
+}
+
+// Write sets the bytes or string value for a given key, expiring it after the expiration unless it is zero.
+func (s *BadgerStore) Write(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return s.db.WriteWithTTL(key, v, expiration)
+	case string:
+		return s.db.WriteWithTTL(key, []byte(v), expiration)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
+	}
+}
+
+// Update atomically replaces the value of the key with the one returned by fn, see Store.Update.
+func (s *BadgerStore) Update(ctx context.Context, key string, fn func(current []byte) ([]byte, error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.db.Update(key, fn)
+}
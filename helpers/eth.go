@@ -2,12 +2,16 @@ package helpers
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	"github.com/txpull/unpack/clients"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // GetBytecode retrieves the bytecode of a contract at a specific address and block number.
@@ -30,3 +34,65 @@ func GetTransactionByHash(ctx context.Context, client *clients.EthClient, hash c
 func GetReceiptByHash(ctx context.Context, client *clients.EthClient, hash common.Hash) (*types.Receipt, error) {
 	return client.GetClient().TransactionReceipt(ctx, hash)
 }
+
+// GetBlockByNumber retrieves the block, including its transactions, at the provided block number.
+func GetBlockByNumber(ctx context.Context, client *clients.EthClient, blockNumber *big.Int) (*types.Block, error) {
+	return client.GetClient().BlockByNumber(ctx, blockNumber)
+}
+
+// GetBlockReceipts retrieves receipts of all of the transactions included in the block at the
+// provided block number, in the order transactions appear in the block.
+func GetBlockReceipts(ctx context.Context, client *clients.EthClient, blockNumber *big.Int) ([]*types.Receipt, error) {
+	block, err := GetBlockByNumber(ctx, client, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*types.Receipt, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		receipt, err := GetReceiptByHash(ctx, client, tx.Hash())
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+// GetRevertData extracts revert data from the error returned by the node for eth_call or eth_estimateGas.
+// It returns false if the error does not carry any revert data.
+func GetRevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+
+	revertData, decodeErr := hexutil.Decode(data)
+	if decodeErr != nil {
+		return nil, false
+	}
+
+	return revertData, true
+}
+
+// GetStorageAt retrieves the value of the storage slot of the contract at the provided block number.
+// If `blockNumber` is nil, the value at the latest block is returned.
+func GetStorageAt(ctx context.Context, client *clients.EthClient, addr common.Address, slot common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return client.GetClient().StorageAt(ctx, addr, slot, blockNumber)
+}
+
+// CallContract executes the read-only message call against the contract at the provided block number.
+func CallContract(ctx context.Context, client *clients.EthClient, addr common.Address, data []byte, blockNumber *big.Int) ([]byte, error) {
+	return client.GetClient().CallContract(ctx, ethereum.CallMsg{To: &addr, Data: data}, blockNumber)
+}
+
+// GetBlockNumber retrieves the number of the most recent block.
+func GetBlockNumber(ctx context.Context, client *clients.EthClient) (uint64, error) {
+	return client.GetClient().BlockNumber(ctx)
+}
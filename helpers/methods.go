@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/types"
+)
+
+// AppendMethodCandidate adds the method to the list of candidates stored for its selector, so that
+// colliding signatures do not overwrite each other. It returns false if the signature is already stored.
+func AppendMethodCandidate(ctx context.Context, store db.Store, chainId *big.Int, method *types.Method) (bool, error) {
+	key := types.GetMethodCandidatesStorageKey(chainId, method.Bytes)
+
+	// Read-modify-write is done atomically, concurrent crawlers would drop each other's candidates otherwise.
+	var appended bool
+	err := store.Update(ctx, key, func(current []byte) ([]byte, error) {
+		appended = false
+
+		var candidates types.Methods
+		if current != nil {
+			if err := candidates.UnmarshalBytes(current); err != nil {
+				return nil, err
+			}
+		}
+
+		if candidates.Contains(method.Signature) {
+			return nil, nil
+		}
+
+		appended = true
+		return append(candidates, method).MarshalBytes()
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return appended, nil
+}
@@ -5,6 +5,7 @@ const (
 	CONTRACT_PROCESS_STATUS_PENDING
 	CONTRACT_PROCESS_STATUS_SUCCESS
 	CONTRACT_PROCESS_STATUS_FAILED
+	CONTRACT_PROCESS_STATUS_MISMATCH
 )
 
 func ContractProcessStatusToString(status int) string {
@@ -17,6 +18,8 @@ func ContractProcessStatusToString(status int) string {
 		return "success"
 	case CONTRACT_PROCESS_STATUS_FAILED:
 		return "failed"
+	case CONTRACT_PROCESS_STATUS_MISMATCH:
+		return "mismatch"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/txpull/unpack/clients"
+)
+
+// callTracerConfig instructs the node to use the built-in callTracer.
+var callTracerConfig = map[string]interface{}{"tracer": "callTracer"}
+
+// CallFrame represents a single call produced by the callTracer.
+type CallFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+}
+
+// TransactionTrace represents callTracer result of a single transaction within the block trace.
+type TransactionTrace struct {
+	TxHash common.Hash `json:"txHash"`
+	Result *CallFrame  `json:"result"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// TraceTransaction retrieves the call tree of the transaction using debug_traceTransaction and the callTracer.
+// Node has to expose debug namespace, usually that means an archive node.
+func TraceTransaction(ctx context.Context, client *clients.EthClient, hash common.Hash) (*CallFrame, error) {
+	var toReturn CallFrame
+	if err := client.GetClient().Client().CallContext(ctx, &toReturn, "debug_traceTransaction", hash, callTracerConfig); err != nil {
+		return nil, err
+	}
+	return &toReturn, nil
+}
+
+// TraceBlockByNumber retrieves call trees of all of the transactions in the block using
+// debug_traceBlockByNumber and the callTracer.
+func TraceBlockByNumber(ctx context.Context, client *clients.EthClient, blockNumber *big.Int) ([]*TransactionTrace, error) {
+	var toReturn []*TransactionTrace
+	if err := client.GetClient().Client().CallContext(ctx, &toReturn, "debug_traceBlockByNumber", hexutil.EncodeBig(blockNumber), callTracerConfig); err != nil {
+		return nil, err
+	}
+	return toReturn, nil
+}
@@ -0,0 +1,76 @@
+package opcodes
+
+import (
+	"fmt"
+)
+
+// Instruction represents a single disassembled EVM instruction.
+type Instruction struct {
+	// Offset is the position of the instruction in the bytecode.
+	Offset uint64 `json:"offset"`
+
+	// OpCode is the instruction opcode.
+	OpCode OpCode `json:"opcode"`
+
+	// Operand holds the immediate data of PUSH instructions. It is empty for any other instruction.
+	Operand []byte `json:"operand,omitempty"`
+
+	// Gas is the constant (base) gas cost of the instruction.
+	Gas uint64 `json:"gas"`
+
+	// Invalid is set when the opcode is not defined.
+	Invalid bool `json:"invalid,omitempty"`
+
+	// Truncated is set when the bytecode ends before all of the PUSH immediate data is read.
+	// In such a case EVM pads the missing operand bytes with zeros.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// String returns the human readable representation of the instruction, e.g. `0x0004 PUSH1 0x80`.
+func (i Instruction) String() string {
+	name := i.OpCode.String()
+	if i.Invalid {
+		name = fmt.Sprintf("INVALID(%#02x)", byte(i.OpCode))
+	}
+
+	if i.OpCode.IsPush() {
+		return fmt.Sprintf("%#04x %s %#x", i.Offset, name, i.Operand)
+	}
+
+	return fmt.Sprintf("%#04x %s", i.Offset, name)
+}
+
+// Disassemble splits the bytecode into instructions. Immediate data of PUSH instructions is
+// attached to the instruction as the operand instead of being interpreted as opcodes.
+// The solidity CBOR metadata trailer, when present, is stripped before disassembling as it is
+// data and not code. Use ParseMetadata to inspect it.
+func Disassemble(code []byte) []Instruction {
+	code, _ = StripMetadata(code)
+
+	instructions := make([]Instruction, 0, len(code))
+
+	for pc := uint64(0); pc < uint64(len(code)); pc++ {
+		op := OpCode(code[pc])
+		instruction := Instruction{
+			Offset:  pc,
+			OpCode:  op,
+			Gas:     op.Gas(),
+			Invalid: !op.IsValid(),
+		}
+
+		if op.IsPush() {
+			size := uint64(op - PUSH1 + 1)
+			end := pc + 1 + size
+			if end > uint64(len(code)) {
+				end = uint64(len(code))
+				instruction.Truncated = true
+			}
+			instruction.Operand = code[pc+1 : end]
+			pc += size
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions
+}
@@ -0,0 +1,77 @@
+package opcodes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisassembler_Disassemble(t *testing.T) {
+	tAssert := assert.New(t)
+
+	// PUSH1 0x80 PUSH1 0x40 MSTORE <undefined 0x0c> PUSH2 0x01 (truncated)
+	code := common.FromHex("0x60806040520c6101")
+
+	instructions := Disassemble(code)
+	tAssert.Len(instructions, 5)
+
+	tAssert.Equal(Instruction{Offset: 0, OpCode: PUSH1, Operand: []byte{0x80}, Gas: 3}, instructions[0])
+	tAssert.Equal(Instruction{Offset: 4, OpCode: MSTORE, Gas: 3}, instructions[2])
+
+	tAssert.True(instructions[3].Invalid)
+	tAssert.Equal(uint64(5), instructions[3].Offset)
+
+	tAssert.Equal(PUSH2, instructions[4].OpCode)
+	tAssert.True(instructions[4].Truncated)
+	tAssert.Equal([]byte{0x01}, instructions[4].Operand)
+	tAssert.Equal("0x0006 PUSH2 0x01", instructions[4].String())
+}
+
+func TestDisassembler_Metadata(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ipfs := append([]byte{0x12, 0x20}, bytes.Repeat([]byte{0xab}, 32)...)
+
+	cbor := []byte{0xa2, 0x64, 'i', 'p', 'f', 's', 0x58, 0x22}
+	cbor = append(cbor, ipfs...)
+	cbor = append(cbor, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x13)
+
+	runtime := common.FromHex("0x6080604052600080fdfe")
+	code := append(append(append([]byte{}, runtime...), cbor...), 0x00, byte(len(cbor)))
+
+	metadata, err := ParseMetadata(code)
+	tAssert.NoError(err)
+	tAssert.Equal(uint64(len(runtime)), metadata.Offset)
+	tAssert.Equal(ipfs, metadata.IPFS)
+	tAssert.Equal("0.8.19", metadata.Solc)
+
+	stripped, metadata := StripMetadata(code)
+	tAssert.NotNil(metadata)
+	tAssert.Equal(runtime, stripped)
+	tAssert.Len(Disassemble(code), 7)
+
+	_, err = ParseMetadata(runtime)
+	tAssert.ErrorIs(err, ErrMetadataNotFound)
+
+	stripped, metadata = StripMetadata(runtime)
+	tAssert.Nil(metadata)
+	tAssert.Equal(runtime, stripped)
+}
+
+func TestDisassembler_ExtractSelectors(t *testing.T) {
+	tAssert := assert.New(t)
+
+	// Simplified solidity dispatcher:
+	// PUSH1 0xe0 SHR DUP1 PUSH4 0xa9059cbb EQ PUSH2 0x0040 JUMPI DUP1 PUSH3 0x70a082 EQ PUSH2 0x0050 JUMPI
+	// PUSH4 0x18160ddd DUP2 EQ PUSH2 0x0060 JUMPI PUSH4 0xffffffff PUSH1 0x00 MSTORE
+	code := common.FromHex("0x60e01c8063a9059cbb1461004057806270a0821461005057" +
+		"6318160ddd811461006057" + "63ffffffff600052")
+
+	tAssert.Equal([][4]byte{
+		{0xa9, 0x05, 0x9c, 0xbb},
+		{0x00, 0x70, 0xa0, 0x82},
+		{0x18, 0x16, 0x0d, 0xdd},
+	}, ExtractSelectors(code))
+}
@@ -0,0 +1,116 @@
+package opcodes
+
+// staticGas holds the constant (base) gas cost of the opcodes as of the Shanghai/Cancun forks.
+// Dynamic costs such as memory expansion, cold account/slot access, copy size or value transfer
+// are not included as they depend on the execution context.
+var staticGas = map[OpCode]uint64{
+	STOP:       0,
+	ADD:        3,
+	MUL:        5,
+	SUB:        3,
+	DIV:        5,
+	SDIV:       5,
+	MOD:        5,
+	SMOD:       5,
+	ADDMOD:     8,
+	MULMOD:     8,
+	EXP:        10,
+	SIGNEXTEND: 5,
+
+	LT:     3,
+	GT:     3,
+	SLT:    3,
+	SGT:    3,
+	EQ:     3,
+	ISZERO: 3,
+	AND:    3,
+	OR:     3,
+	XOR:    3,
+	NOT:    3,
+	BYTE:   3,
+	SHL:    3,
+	SHR:    3,
+	SAR:    3,
+
+	KECCAK256: 30,
+
+	ADDRESS:        2,
+	BALANCE:        100,
+	ORIGIN:         2,
+	CALLER:         2,
+	CALLVALUE:      2,
+	CALLDATALOAD:   3,
+	CALLDATASIZE:   2,
+	CALLDATACOPY:   3,
+	CODESIZE:       2,
+	CODECOPY:       3,
+	GASPRICE:       2,
+	EXTCODESIZE:    100,
+	EXTCODECOPY:    100,
+	RETURNDATASIZE: 2,
+	RETURNDATACOPY: 3,
+	EXTCODEHASH:    100,
+
+	BLOCKHASH:   20,
+	COINBASE:    2,
+	TIMESTAMP:   2,
+	NUMBER:      2,
+	DIFFICULTY:  2,
+	GASLIMIT:    2,
+	CHAINID:     2,
+	SELFBALANCE: 5,
+	BASEFEE:     2,
+	BLOBHASH:    3,
+
+	POP:      2,
+	MLOAD:    3,
+	MSTORE:   3,
+	MSTORE8:  3,
+	SLOAD:    100,
+	SSTORE:   100,
+	JUMP:     8,
+	JUMPI:    10,
+	PC:       2,
+	MSIZE:    2,
+	GAS:      2,
+	JUMPDEST: 1,
+	PUSH0:    2,
+
+	LOG0: 375,
+	LOG1: 750,
+	LOG2: 1125,
+	LOG3: 1500,
+	LOG4: 1875,
+
+	TLOAD:  100,
+	TSTORE: 100,
+
+	CREATE:       32000,
+	CALL:         100,
+	CALLCODE:     100,
+	RETURN:       0,
+	DELEGATECALL: 100,
+	CREATE2:      32000,
+	STATICCALL:   100,
+	REVERT:       0,
+	INVALID:      0,
+	SELFDESTRUCT: 5000,
+}
+
+// Gas returns the constant (base) gas cost of the opcode. PUSH, DUP and SWAP instructions cost 3 gas.
+// Undefined opcodes return 0.
+func (op OpCode) Gas() uint64 {
+	switch {
+	case op.IsPush(), op >= DUP1 && op <= DUP16, op >= SWAP1 && op <= SWAP16:
+		return 3
+	}
+
+	return staticGas[op]
+}
+
+// IsValid reports whether the opcode is defined. Executing undefined opcode aborts the execution
+// in the same way as the designated INVALID (0xfe) instruction does.
+func (op OpCode) IsValid() bool {
+	_, ok := opCodeToString[op]
+	return ok
+}
@@ -0,0 +1,188 @@
+package opcodes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMetadataNotFound is returned when bytecode does not end with the CBOR encoded metadata.
+	ErrMetadataNotFound = errors.New("metadata not found")
+
+	// ErrMalformedMetadata is returned when the metadata could not be decoded.
+	ErrMalformedMetadata = errors.New("malformed metadata")
+)
+
+// Metadata represents the CBOR encoded metadata the solidity compiler appends to the runtime bytecode.
+// See: https://docs.soliditylang.org/en/latest/metadata.html#encoding-of-the-metadata-hash-in-the-bytecode
+type Metadata struct {
+	// Offset is the position in the bytecode at which the metadata begins.
+	Offset uint64 `json:"offset"`
+
+	// Raw holds the CBOR encoded metadata together with its 2 byte length suffix.
+	Raw []byte `json:"raw"`
+
+	// IPFS holds the IPFS multihash of the metadata file.
+	IPFS []byte `json:"ipfs,omitempty"`
+
+	// Bzzr0 holds the Swarm (legacy) hash of the metadata file.
+	Bzzr0 []byte `json:"bzzr0,omitempty"`
+
+	// Bzzr1 holds the Swarm hash of the metadata file.
+	Bzzr1 []byte `json:"bzzr1,omitempty"`
+
+	// Solc holds the compiler version, e.g. 0.8.19. Empty for compilers older than 0.5.9.
+	Solc string `json:"solc,omitempty"`
+
+	// Experimental is set when the contract was compiled with experimental features.
+	Experimental bool `json:"experimental,omitempty"`
+}
+
+// ParseMetadata decodes the CBOR metadata trailer from the end of the bytecode.
+func ParseMetadata(code []byte) (*Metadata, error) {
+	if len(code) < 2 {
+		return nil, ErrMetadataNotFound
+	}
+
+	size := uint64(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if size == 0 || size+2 > uint64(len(code)) {
+		return nil, ErrMetadataNotFound
+	}
+
+	offset := uint64(len(code)) - 2 - size
+	cbor := code[offset : len(code)-2]
+
+	// Metadata is always encoded as the CBOR map with at most a couple of entries.
+	if cbor[0] < 0xa1 || cbor[0] > 0xb7 {
+		return nil, ErrMetadataNotFound
+	}
+
+	toReturn := &Metadata{Offset: offset, Raw: code[offset:]}
+	if err := toReturn.decode(cbor); err != nil {
+		return nil, err
+	}
+
+	return toReturn, nil
+}
+
+// StripMetadata returns the bytecode without the CBOR metadata trailer together with the parsed metadata.
+// Bytecode without (recognisable) metadata is returned unchanged with nil metadata.
+func StripMetadata(code []byte) ([]byte, *Metadata) {
+	metadata, err := ParseMetadata(code)
+	if err != nil {
+		return code, nil
+	}
+
+	return code[:metadata.Offset], metadata
+}
+
+// decode decodes the subset of CBOR used by the compiler: a map of text keys with byte string,
+// text string or boolean values.
+func (m *Metadata) decode(cbor []byte) error {
+	reader := bytes.NewReader(cbor)
+
+	header, _ := reader.ReadByte()
+	entries := int(header & 0x1f)
+
+	known := 0
+	for i := 0; i < entries; i++ {
+		keyType, key, err := readCborItem(reader)
+		if err != nil || keyType != cborText {
+			return ErrMalformedMetadata
+		}
+
+		valueType, value, err := readCborItem(reader)
+		if err != nil {
+			return ErrMalformedMetadata
+		}
+
+		switch string(key) {
+		case "ipfs":
+			m.IPFS, known = value, known+1
+		case "bzzr0":
+			m.Bzzr0, known = value, known+1
+		case "bzzr1":
+			m.Bzzr1, known = value, known+1
+		case "solc":
+			known++
+			if valueType == cborBytes && len(value) == 3 {
+				m.Solc = fmt.Sprintf("%d.%d.%d", value[0], value[1], value[2])
+			} else {
+				m.Solc = string(value)
+			}
+		case "experimental":
+			m.Experimental, known = valueType == cborTrue, known+1
+		}
+	}
+
+	if known == 0 || reader.Len() != 0 {
+		return ErrMalformedMetadata
+	}
+
+	return nil
+}
+
+const (
+	cborBytes = iota
+	cborText
+	cborTrue
+	cborFalse
+)
+
+// readCborItem reads single byte string, text string or boolean CBOR item.
+func readCborItem(reader *bytes.Reader) (int, []byte, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	switch header {
+	case 0xf5:
+		return cborTrue, nil, nil
+	case 0xf4:
+		return cborFalse, nil, nil
+	}
+
+	var itemType int
+	switch header >> 5 {
+	case 2:
+		itemType = cborBytes
+	case 3:
+		itemType = cborText
+	default:
+		return 0, nil, ErrMalformedMetadata
+	}
+
+	length := uint64(header & 0x1f)
+	switch length {
+	case 24:
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length = uint64(b)
+	case 25:
+		var b [2]byte
+		if _, err := reader.Read(b[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(b[:]))
+	default:
+		if length > 23 {
+			return 0, nil, ErrMalformedMetadata
+		}
+	}
+
+	if length > uint64(reader.Len()) {
+		return 0, nil, ErrMalformedMetadata
+	}
+
+	value := make([]byte, length)
+	if _, err := reader.Read(value); err != nil && length > 0 {
+		return 0, nil, err
+	}
+
+	return itemType, value, nil
+}
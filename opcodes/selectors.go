@@ -0,0 +1,60 @@
+package opcodes
+
+// ExtractSelectors scans the function dispatcher of the runtime bytecode and returns the 4 byte
+// function selectors the contract exposes, in order of appearance and without duplicates.
+//
+// The solidity (and vyper) dispatcher compares the selector loaded from calldata with every
+// known selector using the `PUSH4 <selector> EQ PUSH <dest> JUMPI` sequence, optionally with
+// DUP/SWAP instructions in between (`DUP1 PUSH4 <selector> EQ`, `PUSH4 <selector> DUP2 EQ`).
+// Selectors with leading zero bytes may be pushed with the shorter PUSH instruction.
+func ExtractSelectors(code []byte) [][4]byte {
+	instructions := Disassemble(code)
+
+	seen := make(map[[4]byte]struct{})
+	toReturn := make([][4]byte, 0)
+
+	for i, instruction := range instructions {
+		if instruction.OpCode < PUSH1 || instruction.OpCode > PUSH4 || instruction.Truncated {
+			continue
+		}
+
+		if !isDispatcherJump(instructions[i+1:]) {
+			continue
+		}
+
+		var selector [4]byte
+		copy(selector[4-len(instruction.Operand):], instruction.Operand)
+
+		if _, ok := seen[selector]; ok {
+			continue
+		}
+
+		seen[selector] = struct{}{}
+		toReturn = append(toReturn, selector)
+	}
+
+	return toReturn
+}
+
+// isDispatcherJump reports whether instructions following the selector push
+// compare it and conditionally jump to the function body.
+func isDispatcherJump(instructions []Instruction) bool {
+	i := 0
+
+	// Skip stack manipulation between the push and the comparison.
+	for i < len(instructions) && isStackOp(instructions[i].OpCode) {
+		i++
+	}
+
+	if i+2 >= len(instructions) {
+		return false
+	}
+
+	return instructions[i].OpCode == EQ &&
+		instructions[i+1].OpCode.IsPush() &&
+		instructions[i+2].OpCode == JUMPI
+}
+
+func isStackOp(op OpCode) bool {
+	return (op >= DUP1 && op <= DUP16) || (op >= SWAP1 && op <= SWAP16)
+}
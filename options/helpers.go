@@ -58,6 +58,10 @@ func New(optFile string) (*Options, error) {
 		return nil, err
 	}
 
+	if err := migrateLegacyKeys(); err != nil {
+		return nil, err
+	}
+
 	// Unmarshal options into globally accessible struct
 	err := viper.Unmarshal(&globalOptions)
 	if err != nil {
@@ -66,3 +70,36 @@ func New(optFile string) (*Options, error) {
 
 	return &globalOptions, nil
 }
+
+// migrateLegacyKeys maps the BscScan only settings, replaced by the Etherscan-compatible explorers keyed by
+// the network, onto their new keys so that existing configuration files keep working. Settings explicitly
+// provided under the new keys take precedence. Legacy settings that can not be mapped are reported as error
+// rather than being silently ignored.
+func migrateLegacyKeys() error {
+	// [clients.bscscan.api] -> [clients.etherscan.binance] indexing chain 56.
+	if viper.IsSet("clients.bscscan") && !viper.IsSet("clients.etherscan.binance") {
+		viper.Set("clients.etherscan.binance.chain_id", 56)
+		viper.Set("clients.etherscan.binance.api.url", viper.GetString("clients.bscscan.api.url"))
+		viper.Set("clients.etherscan.binance.api.key", viper.GetString("clients.bscscan.api.key"))
+	}
+
+	if !viper.IsSet("syncers.bscscan") || viper.IsSet("syncers.etherscan") {
+		return nil
+	}
+
+	// [syncers.bscscan] -> [syncers.etherscan]. Legacy path pointed directly to the directory holding
+	// the csv export, while every explorer has its own directory named after it under the new path.
+	if legacyPath := viper.GetString("syncers.bscscan.verified_contracts_path"); legacyPath != "" {
+		if filepath.Base(filepath.Clean(legacyPath)) != "bscscan" {
+			return fmt.Errorf(
+				"legacy syncers.bscscan.verified_contracts_path %q is no longer supported, set syncers.etherscan.verified_contracts_path to the directory holding bscscan/verified-contracts.csv",
+				legacyPath,
+			)
+		}
+		viper.Set("syncers.etherscan.verified_contracts_path", filepath.Dir(filepath.Clean(legacyPath)))
+	}
+
+	viper.Set("syncers.etherscan.write_to_clickhouse", viper.GetBool("syncers.bscscan.write_to_clickhouse"))
+
+	return nil
+}
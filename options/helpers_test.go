@@ -0,0 +1,58 @@
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "unpack.toml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %s", err)
+	}
+	return path
+}
+
+func TestOptions_LegacyBscScanKeys(t *testing.T) {
+	tAssert := assert.New(t)
+
+	viper.Reset()
+	defer viper.Reset()
+
+	opts, err := New(writeConfig(t, `
+[clients.bscscan.api]
+url = "https://api.bscscan.com/api"
+key = "legacy-key"
+
+[syncers.bscscan]
+verified_contracts_path = "/home/user/.unpack/bscscan"
+write_to_clickhouse = true
+`))
+	tAssert.NoError(err)
+
+	binance, ok := opts.Clients.Etherscan["binance"]
+	tAssert.True(ok)
+	tAssert.Equal(int64(56), binance.ChainID)
+	tAssert.Equal("https://api.bscscan.com/api", binance.API.URL)
+	tAssert.Equal("legacy-key", binance.API.Key)
+
+	tAssert.Equal("/home/user/.unpack", opts.Syncers.Etherscan.VerifiedContractsPath)
+	tAssert.True(opts.Syncers.Etherscan.WriteToClickhouse)
+}
+
+func TestOptions_LegacyBscScanPathNotMappable(t *testing.T) {
+	tAssert := assert.New(t)
+
+	viper.Reset()
+	defer viper.Reset()
+
+	_, err := New(writeConfig(t, `
+[syncers.bscscan]
+verified_contracts_path = "/data/contracts"
+`))
+	tAssert.ErrorContains(err, "syncers.bscscan.verified_contracts_path")
+}
@@ -16,6 +16,7 @@ type Options struct {
 type Networks struct {
 	Ethereum Nodes `mapstructure:"ethereum"`
 	Binance  Nodes `mapstructure:"binance"`
+	Polygon  Nodes `mapstructure:"polygon"`
 }
 
 // GetNode returns the node settings for a given network and node.
@@ -36,6 +37,13 @@ func (o *Options) GetNode(network string, node string) Node {
 		case "archive":
 			return o.Networks.Binance.ArchiveNode
 		}
+	case "polygon":
+		switch node {
+		case "full":
+			return o.Networks.Polygon.FullNode
+		case "archive":
+			return o.Networks.Polygon.ArchiveNode
+		}
 	}
 
 	return Node{}
@@ -62,15 +70,17 @@ type Fixtures struct {
 	EndBlockNumber   uint64 `mapstructure:"end_block_number"`
 }
 
-// Clients is a struct that holds the Bscscan and Bitquery client settings.
+// Clients is a struct that holds the Etherscan-family and Bitquery client settings.
 type Clients struct {
-	Bscscan  BscscanClient  `mapstructure:"bscscan"`
-	Bitquery BitqueryClient `mapstructure:"bitquery"`
+	Etherscan map[string]EtherscanClient `mapstructure:"etherscan"`
+	Bitquery  BitqueryClient             `mapstructure:"bitquery"`
 }
 
-// BscscanClient is a struct that holds the API settings for the Bscscan client.
-type BscscanClient struct {
-	API ClientInfo `mapstructure:"api"`
+// EtherscanClient is a struct that holds the API settings for the Etherscan-compatible explorer of a single chain.
+type EtherscanClient struct {
+	ChainID    int64      `mapstructure:"chain_id"`
+	API        ClientInfo `mapstructure:"api"`
+	RateLimitS int        `mapstructure:"rate_limit_s"`
 }
 
 // BitqueryClient is a struct that holds the API settings for the Bitquery client.
@@ -84,10 +94,17 @@ type ClientInfo struct {
 	Key string `mapstructure:"key"`
 }
 
-// Database is a struct that holds the Redis and Clickhouse database settings.
+// Database is a struct that holds the Redis, Clickhouse and BadgerDB database settings.
 type Database struct {
 	Redis      Redis      `mapstructure:"redis"`
 	Clickhouse ClickHouse `mapstructure:"clickhouse"`
+	Badger     Badger     `mapstructure:"badger"`
+}
+
+// Badger is a struct that holds the settings for the embedded BadgerDB database.
+// When the path is set, the syncers store into BadgerDB instead of Redis.
+type Badger struct {
+	Path string `mapstructure:"path"`
 }
 
 // Redis is a struct that holds the settings for a Redis database.
@@ -116,9 +133,9 @@ type ClickHouse struct {
 
 // Syncers is a struct that holds the settings for different syncers.
 type Syncers struct {
-	Fourbyte FourbyteSyncer `mapstructure:"fourbyte"`
-	Bscscan  BscscanSyncer  `mapstructure:"bscscan"`
-	Sourcify SourcifySyncer `mapstructure:"sourcify"`
+	Fourbyte  FourbyteSyncer  `mapstructure:"fourbyte"`
+	Etherscan EtherscanSyncer `mapstructure:"etherscan"`
+	Sourcify  SourcifySyncer  `mapstructure:"sourcify"`
 }
 
 // FourbyteSyncer is a struct that holds the settings for a Fourbyte syncer.
@@ -128,10 +145,11 @@ type FourbyteSyncer struct {
 	ChainID           int    `mapstructure:"chain_id"`
 }
 
-// BscscanSyncer is a struct that holds the settings for a Bscscan syncer.
-type BscscanSyncer struct {
+// EtherscanSyncer is a struct that holds the settings for an Etherscan-family syncer.
+type EtherscanSyncer struct {
 	VerifiedContractsPath string `mapstructure:"verified_contracts_path"`
 	WriteToClickhouse     bool   `mapstructure:"write_to_clickhouse"`
+	SolcPath              string `mapstructure:"solc_path"`
 }
 
 // SourcifySyncer is a struct that holds the settings for a Sourcify syncer.
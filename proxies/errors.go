@@ -0,0 +1,11 @@
+package proxies
+
+import "errors"
+
+var (
+	// ErrNotProxy is returned when the contract does not match any of the known proxy patterns.
+	ErrNotProxy = errors.New("contract is not a proxy")
+
+	// ErrNoCode is returned when there is no code deployed at the address.
+	ErrNoCode = errors.New("no code at address")
+)
@@ -0,0 +1,253 @@
+// Package proxies detects proxy contracts and resolves the implementation contract they delegate calls to.
+package proxies
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/opcodes"
+	"github.com/txpull/unpack/types"
+)
+
+var (
+	// eip1967ImplementationSlot is bytes32(uint256(keccak256('eip1967.proxy.implementation')) - 1).
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// eip1967AdminSlot is bytes32(uint256(keccak256('eip1967.proxy.admin')) - 1).
+	eip1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+	// eip1967BeaconSlot is bytes32(uint256(keccak256('eip1967.proxy.beacon')) - 1).
+	eip1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+	// eip1822ProxiableSlot is keccak256('PROXIABLE').
+	eip1822ProxiableSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+
+	// openZeppelinImplementationSlot is keccak256('org.zeppelinos.proxy.implementation') used by the legacy ZeppelinOS proxies.
+	openZeppelinImplementationSlot = common.HexToHash("0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+
+	// implementationSelector is the selector of implementation() defined by EIP-897 and beacons.
+	implementationSelector = common.FromHex("0x5c60da1b")
+
+	// masterCopySelector is the selector of masterCopy() exposed by the Gnosis Safe proxies.
+	masterCopySelector = common.FromHex("0xa619486e")
+
+	// eip1167Prefix and eip1167Suffix surround the implementation address push of the EIP-1167 minimal proxy.
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d")
+	eip1167Suffix = common.FromHex("0x5af43d82803e903d91")
+)
+
+// Resolver detects proxy contracts through their storage slots and bytecode patterns.
+type Resolver struct {
+	ctx       context.Context
+	ethClient *clients.EthClient
+}
+
+// Option defines a function type that applies configurations to a Resolver.
+type Option func(*Resolver)
+
+func WithEthClient(client *clients.EthClient) Option {
+	return func(r *Resolver) {
+		r.ethClient = client
+	}
+}
+
+func NewResolver(ctx context.Context, opts ...Option) (*Resolver, error) {
+	resolver := &Resolver{ctx: ctx}
+
+	for _, opt := range opts {
+		opt(resolver)
+	}
+
+	if resolver.ethClient == nil {
+		return nil, errors.New("eth client is required")
+	}
+
+	return resolver, nil
+}
+
+// Resolve detects whether the contract at the address is a proxy and returns the link to its implementation.
+// Detection is done at the provided block number, or at the latest block if `blockNumber` is nil.
+// ErrNotProxy is returned for contracts not matching any of the known proxy patterns.
+func (r *Resolver) Resolve(chainId *big.Int, addr common.Address, blockNumber *big.Int) (*types.Proxy, error) {
+	code, err := helpers.GetBytecode(r.ctx, r.ethClient, addr, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bytecode: %w", err)
+	}
+
+	if len(code) == 0 {
+		return nil, ErrNoCode
+	}
+
+	proxy := &types.Proxy{
+		UUID:    uuid.New(),
+		ChainID: chainId,
+		Address: addr,
+	}
+
+	if implementation, ok := MinimalProxyImplementation(code); ok {
+		proxy.Type = types.ProxyTypeEIP1167
+		proxy.Implementation = implementation
+		return proxy, nil
+	}
+
+	// Proxies have to delegate calls, there is no point in further (network expensive) checks otherwise.
+	if !hasDelegateCall(code) {
+		return nil, ErrNotProxy
+	}
+
+	if implementation, ok, err := r.readAddressSlot(addr, eip1967ImplementationSlot, blockNumber); err != nil {
+		return nil, err
+	} else if ok {
+		proxy.Type = types.ProxyTypeEIP1967
+		proxy.Implementation = implementation
+
+		if _, isTransparent, err := r.readAddressSlot(addr, eip1967AdminSlot, blockNumber); err != nil {
+			return nil, err
+		} else if isTransparent {
+			proxy.Type = types.ProxyTypeTransparent
+		}
+
+		return proxy, nil
+	}
+
+	if beacon, ok, err := r.readAddressSlot(addr, eip1967BeaconSlot, blockNumber); err != nil {
+		return nil, err
+	} else if ok {
+		implementation, ok := r.callAddress(beacon, implementationSelector, blockNumber)
+		if !ok {
+			return nil, ErrNotProxy
+		}
+
+		proxy.Type = types.ProxyTypeEIP1967Beacon
+		proxy.Implementation = implementation
+		proxy.Beacon = &beacon
+		return proxy, nil
+	}
+
+	slots := []struct {
+		slot      common.Hash
+		proxyType types.ProxyType
+	}{
+		{slot: eip1822ProxiableSlot, proxyType: types.ProxyTypeEIP1822},
+		{slot: openZeppelinImplementationSlot, proxyType: types.ProxyTypeOpenZeppelin},
+	}
+
+	for _, s := range slots {
+		implementation, ok, err := r.readAddressSlot(addr, s.slot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			proxy.Type = s.proxyType
+			proxy.Implementation = implementation
+			return proxy, nil
+		}
+	}
+
+	if implementation, ok := r.callAddress(addr, masterCopySelector, blockNumber); ok {
+		proxy.Type = types.ProxyTypeGnosisSafe
+		proxy.Implementation = implementation
+		return proxy, nil
+	}
+
+	if implementation, ok := r.callAddress(addr, implementationSelector, blockNumber); ok {
+		proxy.Type = types.ProxyTypeEIP897
+		proxy.Implementation = implementation
+		return proxy, nil
+	}
+
+	return nil, ErrNotProxy
+}
+
+// MinimalProxyImplementation extracts the implementation address from the EIP-1167 minimal proxy bytecode.
+// Vanity implementation addresses with leading zero bytes may be pushed with the shorter PUSH instruction.
+func MinimalProxyImplementation(code []byte) (common.Address, bool) {
+	if !bytes.HasPrefix(code, eip1167Prefix) || len(code) <= len(eip1167Prefix) {
+		return common.Address{}, false
+	}
+
+	push := opcodes.OpCode(code[len(eip1167Prefix)])
+	if push < opcodes.PUSH1 || push > opcodes.PUSH20 {
+		return common.Address{}, false
+	}
+
+	start := len(eip1167Prefix) + 1
+	end := start + int(push-opcodes.PUSH1) + 1
+	if len(code) < end || !bytes.HasPrefix(code[end:], eip1167Suffix) {
+		return common.Address{}, false
+	}
+
+	return common.BytesToAddress(code[start:end]), true
+}
+
+// readAddressSlot reads the address stored in the storage slot. It returns false for the empty slot
+// or when the slot holds the value that is not an address.
+func (r *Resolver) readAddressSlot(addr common.Address, slot common.Hash, blockNumber *big.Int) (common.Address, bool, error) {
+	value, err := helpers.GetStorageAt(r.ctx, r.ethClient, addr, slot, blockNumber)
+	if err != nil {
+		return common.Address{}, false, fmt.Errorf("failed to get storage slot %s: %w", slot.Hex(), err)
+	}
+
+	address, ok := toAddress(value)
+	return address, ok, nil
+}
+
+// callAddress calls the address returning method and verifies that returned address holds the code.
+// Contracts with the fallback function may return arbitrary data for any call, hence the verification.
+func (r *Resolver) callAddress(addr common.Address, selector []byte, blockNumber *big.Int) (common.Address, bool) {
+	result, err := helpers.CallContract(r.ctx, r.ethClient, addr, selector, blockNumber)
+	if err != nil || len(result) != common.HashLength {
+		return common.Address{}, false
+	}
+
+	address, ok := toAddress(result)
+	if !ok {
+		return common.Address{}, false
+	}
+
+	code, err := helpers.GetBytecode(r.ctx, r.ethClient, address, blockNumber)
+	if err != nil || len(code) == 0 {
+		return common.Address{}, false
+	}
+
+	return address, true
+}
+
+// toAddress converts the 32 byte word into the address. It returns false for the zero word
+// or when upper 12 bytes are not zero.
+func toAddress(word []byte) (common.Address, bool) {
+	if len(word) != common.HashLength {
+		return common.Address{}, false
+	}
+
+	for _, b := range word[:common.HashLength-common.AddressLength] {
+		if b != 0 {
+			return common.Address{}, false
+		}
+	}
+
+	address := common.BytesToAddress(word)
+	if address == (common.Address{}) {
+		return common.Address{}, false
+	}
+
+	return address, true
+}
+
+func hasDelegateCall(code []byte) bool {
+	for _, instruction := range opcodes.Disassemble(code) {
+		if instruction.OpCode == opcodes.DELEGATECALL {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package proxies
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_MinimalProxyImplementation(t *testing.T) {
+	tAssert := assert.New(t)
+
+	implementation := common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe")
+
+	code := common.FromHex("0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3")
+	address, ok := MinimalProxyImplementation(code)
+	tAssert.True(ok)
+	tAssert.Equal(implementation, address)
+
+	// Vanity address with leading zero bytes pushed with PUSH18.
+	code = common.FromHex("0x363d3d373d3d3d363d71bebebebebebebebebebebebebebebebebebe5af43d82803e903d91602957fd5bf3")
+	address, ok = MinimalProxyImplementation(code)
+	tAssert.True(ok)
+	tAssert.Equal(common.HexToAddress("0x0000bebebebebebebebebebebebebebebebebebe"), address)
+
+	_, ok = MinimalProxyImplementation(common.FromHex("0x6080604052"))
+	tAssert.False(ok)
+}
+
+func TestResolver_ToAddress(t *testing.T) {
+	tAssert := assert.New(t)
+
+	address, ok := toAddress(common.HexToHash("0x000000000000000000000000bebebebebebebebebebebebebebebebebebebebe").Bytes())
+	tAssert.True(ok)
+	tAssert.Equal(common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe"), address)
+
+	_, ok = toAddress(common.Hash{}.Bytes())
+	tAssert.False(ok)
+
+	_, ok = toAddress(common.HexToHash("0x0100000000000000000000000000000000000000000000000000000000000001").Bytes())
+	tAssert.False(ok)
+}
@@ -11,4 +11,14 @@ var (
 
 	// ErrRecordNotFound is returned when a record is not found
 	ErrRecordNotFound = errors.New("record not found")
+
+	// ErrReadersFailed is returned when the record was not found and at least one of the readers failed,
+	// meaning the record might exist but could not be read.
+	ErrReadersFailed = errors.New("readers failed")
+
+	// ErrNoReaders is returned when the manager has no enabled readers to look records up with
+	ErrNoReaders = errors.New("no readers available")
+
+	// ErrInvalidCacheSize is returned when the caching reader is configured with a size that is not positive
+	ErrInvalidCacheSize = errors.New("invalid cache size")
 )
@@ -0,0 +1,436 @@
+package readers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/types"
+	"go.uber.org/zap"
+)
+
+// Strategy defines how the Manager looks records up across its readers.
+type Strategy int
+
+const (
+	// StrategyFirstHit asks the readers one by one, in priority order, until one of them has the record.
+	// Batch lookups ask every following reader only for the records the preceding ones did not have.
+	StrategyFirstHit Strategy = iota
+
+	// StrategyRace asks all of the readers at once and returns the first record found, cancelling the
+	// rest of the lookups. Batch lookups wait for every reader and merge the records in priority order.
+	StrategyRace
+
+	// StrategyMerge asks all of the readers and combines the method candidates of every reader. Records keyed
+	// by the signature, hash or address can not be merged, as the record of the reader with higher priority
+	// always wins. Such lookups, batch ones included, fall back to StrategyFirstHit and lower priority readers
+	// are asked only for the records the preceding ones did not have.
+	StrategyMerge
+)
+
+// String returns the name of the strategy.
+func (s Strategy) String() string {
+	switch s {
+	case StrategyFirstHit:
+		return "first_hit"
+	case StrategyRace:
+		return "race"
+	case StrategyMerge:
+		return "merge"
+	default:
+		return "unknown"
+	}
+}
+
+// Writer is implemented by readers able to store records. The Manager uses it to write the records
+// served by the slower readers back into the faster ones that missed them.
+type Writer interface {
+	WriteContract(ctx context.Context, chainId *big.Int, contract *types.Contract) error
+
+	WriteMethod(ctx context.Context, chainId *big.Int, method *types.Method) error
+
+	WriteEvent(ctx context.Context, chainId *big.Int, event *types.Event) error
+
+	WriteError(ctx context.Context, chainId *big.Int, customError *types.Error) error
+}
+
+// HitHook is called with the lookup name (e.g. GetEventByHash) and the name of the reader that served the record.
+type HitHook func(lookup string, reader string)
+
+// namedReader pairs the reader with the name it was added to the Manager under.
+type namedReader struct {
+	name   string
+	reader Reader
+}
+
+// result is the outcome of the single record lookup of one reader.
+type result[T any] struct {
+	index int
+	value T
+	err   error
+}
+
+// lookup runs the single record lookup across the readers according to the Manager strategy. StrategyMerge
+// behaves as StrategyFirstHit, there is nothing to merge the single record with. The record of the reader
+// that served it is written back into the readers that missed it, if write back is enabled.
+func lookup[T any](
+	ctx context.Context,
+	m *Manager,
+	name string,
+	get func(context.Context, Reader) (T, error),
+	found func(T) bool,
+	write func(context.Context, Writer, T) error,
+) (T, error) {
+	var empty T
+
+	readers := m.sortedReaders()
+	if len(readers) == 0 {
+		return empty, ErrNoReaders
+	}
+
+	results := make([]result[T], 0, len(readers))
+
+	if m.strategy == StrategyRace {
+		raceCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		ch := make(chan result[T], len(readers))
+		for i, r := range readers {
+			go func(i int, r namedReader) {
+				value, err := get(raceCtx, r.reader)
+				ch <- result[T]{index: i, value: value, err: err}
+			}(i, r)
+		}
+
+		for range readers {
+			res := <-ch
+			results = append(results, res)
+			if res.err == nil && found(res.value) {
+				cancel()
+				break
+			}
+		}
+	} else {
+		for i, r := range readers {
+			value, err := get(ctx, r.reader)
+			results = append(results, result[T]{index: i, value: value, err: err})
+			if err == nil && found(value) {
+				break
+			}
+		}
+	}
+
+	hit := results[len(results)-1]
+	if hit.err != nil || !found(hit.value) {
+		return empty, lookupError(ctx, readers, results)
+	}
+
+	m.reportHit(name, readers[hit.index].name)
+
+	if m.writeBack && write != nil {
+		for _, res := range results[:len(results)-1] {
+			if isMiss(res.err) && res.index < hit.index {
+				m.writeRecord(ctx, name, readers[res.index], func(ctx context.Context, w Writer) error {
+					return write(ctx, w, hit.value)
+				})
+			}
+		}
+	}
+
+	return hit.value, nil
+}
+
+// lookupBatch runs the batch lookup across the readers according to the Manager strategy. StrategyMerge
+// behaves as StrategyFirstHit, records are merged in priority order either way. Records are written back
+// into the readers that were asked for them and missed them.
+func lookupBatch[K comparable, V any](
+	ctx context.Context,
+	m *Manager,
+	name string,
+	keys []K,
+	get func(context.Context, Reader, []K) (map[K]V, error),
+	write func(context.Context, Writer, V) error,
+) (map[K]V, error) {
+	readers := m.sortedReaders()
+	if len(readers) == 0 {
+		return nil, ErrNoReaders
+	}
+
+	asked := make([][]K, len(readers))
+	answers := make([]map[K]V, len(readers))
+	errs := make([]error, len(readers))
+
+	if m.strategy == StrategyRace {
+		done := make(chan int, len(readers))
+		for i, r := range readers {
+			asked[i] = keys
+			go func(i int, r namedReader) {
+				answers[i], errs[i] = get(ctx, r.reader, keys)
+				done <- i
+			}(i, r)
+		}
+
+		for range readers {
+			<-done
+		}
+	} else {
+		missing := keys
+		for i, r := range readers {
+			if len(missing) == 0 {
+				break
+			}
+
+			asked[i] = missing
+			answers[i], errs[i] = get(ctx, r.reader, asked[i])
+			if errs[i] != nil {
+				continue
+			}
+
+			next := make([]K, 0, len(missing))
+			for _, key := range missing {
+				if _, ok := answers[i][key]; !ok {
+					next = append(next, key)
+				}
+			}
+			missing = next
+		}
+	}
+
+	toReturn := make(map[K]V, len(keys))
+	servedBy := make(map[K]int, len(keys))
+
+	failed := 0
+	for i := range readers {
+		if errs[i] != nil {
+			failed++
+			continue
+		}
+
+		for key, value := range answers[i] {
+			if _, ok := toReturn[key]; !ok {
+				toReturn[key] = value
+				servedBy[key] = i
+				m.reportHit(name, readers[i].name)
+			}
+		}
+	}
+
+	// Some of the records might still be found when only a part of the readers failed.
+	if failed > 0 && len(toReturn) == 0 {
+		results := make([]result[V], 0, len(readers))
+		for i := range readers {
+			results = append(results, result[V]{index: i, err: errs[i]})
+		}
+		return nil, lookupError(ctx, readers, results)
+	}
+
+	if m.writeBack && write != nil {
+		for i := range readers {
+			if errs[i] != nil || asked[i] == nil {
+				continue
+			}
+
+			for _, key := range asked[i] {
+				index, ok := servedBy[key]
+				if _, answered := answers[i][key]; !ok || answered || index <= i {
+					continue
+				}
+
+				value := toReturn[key]
+				m.writeRecord(ctx, name, readers[i], func(ctx context.Context, w Writer) error {
+					return write(ctx, w, value)
+				})
+			}
+		}
+	}
+
+	return toReturn, nil
+}
+
+// lookupError returns ErrRecordNotFound when every reader missed the record and ErrReadersFailed
+// with the reader failures otherwise. Lookup of the cancelled (or timed out) caller fails with the
+// context error, so that it is not mistaken for the miss, e.g. by the caching reader.
+func lookupError[T any](ctx context.Context, readers []namedReader, results []result[T]) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, res := range results {
+		if !isMiss(res.err) {
+			failures = append(failures, fmt.Sprintf("%s: %s", readers[res.index].name, res.err))
+		}
+	}
+
+	if len(failures) == 0 {
+		return ErrRecordNotFound
+	}
+
+	return fmt.Errorf("%w: %s", ErrReadersFailed, strings.Join(failures, "; "))
+}
+
+// isMiss reports whether the reader answered the lookup without the record, as opposed to failing.
+func isMiss(err error) bool {
+	return err == nil || errors.Is(err, ErrRecordNotFound)
+}
+
+func (m *Manager) reportHit(lookup string, reader string) {
+	if m.hitHook != nil {
+		m.hitHook(lookup, reader)
+	}
+}
+
+// writeRecord writes the record back into the reader, if it is a Writer. Failures are only logged as
+// the record was already served.
+func (m *Manager) writeRecord(ctx context.Context, lookup string, r namedReader, write func(context.Context, Writer) error) {
+	writer, ok := r.reader.(Writer)
+	if !ok {
+		return
+	}
+
+	if err := write(ctx, writer); err != nil {
+		zap.L().Error(
+			"failed to write record back into the reader",
+			zap.String("lookup", lookup),
+			zap.String("reader_name", r.name),
+			zap.Error(err),
+		)
+	}
+}
+
+func (m *Manager) GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error) {
+	return lookup(ctx, m, "GetContractByAddress",
+		func(ctx context.Context, r Reader) (*types.Contract, error) {
+			return r.GetContractByAddress(ctx, chainId, address)
+		},
+		func(contract *types.Contract) bool { return contract != nil },
+		func(ctx context.Context, w Writer, contract *types.Contract) error {
+			return w.WriteContract(ctx, chainId, contract)
+		},
+	)
+}
+
+func (m *Manager) GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+	return lookupBatch(ctx, m, "GetContractsByAddresses", addresses,
+		func(ctx context.Context, r Reader, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+			return r.GetContractsByAddresses(ctx, chainId, addresses)
+		},
+		func(ctx context.Context, w Writer, contract *types.Contract) error {
+			return w.WriteContract(ctx, chainId, contract)
+		},
+	)
+}
+
+func (m *Manager) GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error) {
+	return lookup(ctx, m, "GetMethodBySignature",
+		func(ctx context.Context, r Reader) (*types.Method, error) {
+			return r.GetMethodBySignature(ctx, chainId, signature)
+		},
+		func(method *types.Method) bool { return method != nil },
+		func(ctx context.Context, w Writer, method *types.Method) error {
+			return w.WriteMethod(ctx, chainId, method)
+		},
+	)
+}
+
+func (m *Manager) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	return lookupBatch(ctx, m, "GetMethodsBySignatures", signatures,
+		func(ctx context.Context, r Reader, signatures []string) (map[string]*types.Method, error) {
+			return r.GetMethodsBySignatures(ctx, chainId, signatures)
+		},
+		func(ctx context.Context, w Writer, method *types.Method) error {
+			return w.WriteMethod(ctx, chainId, method)
+		},
+	)
+}
+
+// GetMethodCandidates returns the methods sharing the selector. With StrategyMerge candidates of every
+// reader are combined, otherwise candidates of the first reader having any are returned. Candidate lists
+// are maintained by the crawlers and are never written back.
+func (m *Manager) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	get := func(ctx context.Context, r Reader) (types.Methods, error) {
+		return r.GetMethodCandidates(ctx, chainId, selector)
+	}
+
+	if m.strategy != StrategyMerge {
+		return lookup(ctx, m, "GetMethodCandidates", get, func(methods types.Methods) bool { return len(methods) > 0 }, nil)
+	}
+
+	readers := m.sortedReaders()
+	if len(readers) == 0 {
+		return nil, ErrNoReaders
+	}
+
+	var toReturn types.Methods
+	results := make([]result[types.Methods], 0, len(readers))
+	for i, r := range readers {
+		methods, err := get(ctx, r.reader)
+		results = append(results, result[types.Methods]{index: i, err: err})
+		if err != nil {
+			continue
+		}
+
+		for _, method := range methods {
+			if method != nil && !toReturn.Contains(method.Signature) {
+				toReturn = append(toReturn, method)
+				m.reportHit("GetMethodCandidates", r.name)
+			}
+		}
+	}
+
+	if len(toReturn) == 0 {
+		return nil, lookupError(ctx, readers, results)
+	}
+
+	return toReturn, nil
+}
+
+func (m *Manager) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	return lookup(ctx, m, "GetEventByHash",
+		func(ctx context.Context, r Reader) (*types.Event, error) {
+			return r.GetEventByHash(ctx, chainId, hash)
+		},
+		func(event *types.Event) bool { return event != nil },
+		func(ctx context.Context, w Writer, event *types.Event) error {
+			return w.WriteEvent(ctx, chainId, event)
+		},
+	)
+}
+
+func (m *Manager) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	return lookupBatch(ctx, m, "GetEventsByHashes", hashes,
+		func(ctx context.Context, r Reader, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+			return r.GetEventsByHashes(ctx, chainId, hashes)
+		},
+		func(ctx context.Context, w Writer, event *types.Event) error {
+			return w.WriteEvent(ctx, chainId, event)
+		},
+	)
+}
+
+func (m *Manager) GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error) {
+	return lookup(ctx, m, "GetErrorBySignature",
+		func(ctx context.Context, r Reader) (*types.Error, error) {
+			return r.GetErrorBySignature(ctx, chainId, signature)
+		},
+		func(customError *types.Error) bool { return customError != nil },
+		func(ctx context.Context, w Writer, customError *types.Error) error {
+			return w.WriteError(ctx, chainId, customError)
+		},
+	)
+}
+
+// String returns the name of the Manager, which is the names of its readers in priority order.
+func (m *Manager) String() string {
+	readers := m.sortedReaders()
+
+	names := make([]string, 0, len(readers))
+	for _, r := range readers {
+		names = append(names, r.name)
+	}
+
+	return "manager(" + strings.Join(names, ",") + ")"
+}
@@ -0,0 +1,297 @@
+package readers
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/types"
+)
+
+// eventReader serves the stored events, optionally failing or delaying every lookup, and records the writes.
+type eventReader struct {
+	MockReader
+	events  map[common.Hash]*types.Event
+	err     error
+	delay   time.Duration
+	mu      sync.Mutex
+	calls   int
+	batches [][]common.Hash
+	written []common.Hash
+}
+
+func (r *eventReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+
+	if r.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(r.delay):
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if event, ok := r.events[hash]; ok {
+		return event, nil
+	}
+
+	return nil, ErrRecordNotFound
+}
+
+func (r *eventReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, hashes)
+	r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	toReturn := make(map[common.Hash]*types.Event)
+	for _, hash := range hashes {
+		if event, ok := r.events[hash]; ok {
+			toReturn[hash] = event
+		}
+	}
+	return toReturn, nil
+}
+
+func (r *eventReader) WriteContract(ctx context.Context, chainId *big.Int, contract *types.Contract) error {
+	return nil
+}
+
+func (r *eventReader) WriteMethod(ctx context.Context, chainId *big.Int, method *types.Method) error {
+	return nil
+}
+
+func (r *eventReader) WriteEvent(ctx context.Context, chainId *big.Int, event *types.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.written = append(r.written, event.Hash)
+	return nil
+}
+
+func (r *eventReader) WriteError(ctx context.Context, chainId *big.Int, customError *types.Error) error {
+	return nil
+}
+
+func TestManager_Lookup(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	approval := &types.Event{Name: "Approval", Hash: common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")}
+
+	redis := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}}
+	clickhouse := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer, approval.Hash: approval}}
+
+	var hits []string
+	manager, err := NewManager(ctx,
+		WithReader("redis", redis),
+		WithReader("clickhouse", clickhouse),
+		WithPriorityReader("redis"),
+		WithWriteBack(true),
+		WithHitHook(func(lookup string, reader string) {
+			hits = append(hits, lookup+":"+reader)
+		}),
+	)
+	tAssert.NoError(err)
+
+	// First hit is served by the priority reader without asking the other one.
+	event, err := manager.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(transfer, event)
+	tAssert.Equal(0, clickhouse.calls)
+
+	// Miss in redis is served by clickhouse and written back into redis.
+	event, err = manager.GetEventByHash(ctx, chainId, approval.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(approval, event)
+	tAssert.Equal([]common.Hash{approval.Hash}, redis.written)
+	tAssert.Empty(clickhouse.written)
+	tAssert.Equal([]string{"GetEventByHash:redis", "GetEventByHash:clickhouse"}, hits)
+
+	_, err = manager.GetEventByHash(ctx, chainId, common.HexToHash("0x01"))
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+
+	// Backend failure is not reported as the missing record.
+	clickhouse.err = errors.New("connection refused")
+	_, err = manager.GetEventByHash(ctx, chainId, common.HexToHash("0x01"))
+	tAssert.ErrorIs(err, ErrReadersFailed)
+	tAssert.NotErrorIs(err, ErrRecordNotFound)
+	tAssert.Contains(err.Error(), "clickhouse: connection refused")
+
+	// Failing reader does not hide the record found by another one.
+	event, err = manager.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(transfer, event)
+
+	_, err = (&Manager{readers: map[string]Reader{}}).GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.ErrorIs(err, ErrNoReaders)
+}
+
+func TestManager_LookupRace(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+
+	slow := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}, delay: time.Minute}
+	fast := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}}
+
+	var served string
+	manager, err := NewManager(ctx,
+		WithReader("slow", slow),
+		WithReader("fast", fast),
+		WithPriorityReader("slow"),
+		WithStrategy(StrategyRace),
+		WithHitHook(func(lookup string, reader string) {
+			served = reader
+		}),
+	)
+	tAssert.NoError(err)
+
+	start := time.Now()
+	event, err := manager.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(transfer, event)
+	tAssert.Equal("fast", served)
+	tAssert.Less(time.Since(start), time.Minute)
+}
+
+func TestManager_LookupBatch(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	approval := &types.Event{Name: "Approval", Hash: common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")}
+	unknown := common.HexToHash("0x01")
+
+	for _, strategy := range []Strategy{StrategyFirstHit, StrategyRace, StrategyMerge} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			redis := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}}
+			clickhouse := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer, approval.Hash: approval}}
+
+			manager, err := NewManager(ctx,
+				WithReader("redis", redis),
+				WithReader("clickhouse", clickhouse),
+				WithPriorityReader("redis"),
+				WithStrategy(strategy),
+				WithWriteBack(true),
+			)
+			tAssert.NoError(err)
+
+			events, err := manager.GetEventsByHashes(ctx, chainId, []common.Hash{transfer.Hash, approval.Hash, unknown})
+			tAssert.NoError(err)
+			tAssert.Len(events, 2)
+			tAssert.Equal(transfer, events[transfer.Hash])
+			tAssert.Equal(approval, events[approval.Hash])
+
+			// Only the record redis missed is written back into it.
+			tAssert.Equal([]common.Hash{approval.Hash}, redis.written)
+			tAssert.Empty(clickhouse.written)
+		})
+	}
+}
+
+func TestManager_LookupMerge(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	partial := &types.Event{Name: "Transfer", Hash: transfer.Hash, IsPartial: true}
+	approval := &types.Event{Name: "Approval", Hash: common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")}
+
+	redis := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}}
+	clickhouse := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: partial, approval.Hash: approval}}
+
+	manager, err := NewManager(ctx,
+		WithReader("redis", redis),
+		WithReader("clickhouse", clickhouse),
+		WithPriorityReader("redis"),
+		WithStrategy(StrategyMerge),
+		WithWriteBack(true),
+	)
+	tAssert.NoError(err)
+
+	// Single record lookup falls back to the first hit, record of the priority reader wins and
+	// the lower priority reader is not asked at all.
+	event, err := manager.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.NoError(err)
+	tAssert.Same(transfer, event)
+	tAssert.Equal(0, clickhouse.calls)
+
+	// Miss is served by the lower priority reader and written back, the same as with the first hit.
+	event, err = manager.GetEventByHash(ctx, chainId, approval.Hash)
+	tAssert.NoError(err)
+	tAssert.Same(approval, event)
+	tAssert.Equal(1, clickhouse.calls)
+	tAssert.Equal([]common.Hash{approval.Hash}, redis.written)
+
+	// Batch lookup falls back to the first hit as well, the lower priority reader is asked only for
+	// the records the priority reader did not have.
+	events, err := manager.GetEventsByHashes(ctx, chainId, []common.Hash{transfer.Hash})
+	tAssert.NoError(err)
+	tAssert.Same(transfer, events[transfer.Hash])
+	tAssert.Empty(clickhouse.batches)
+
+	redis.events[approval.Hash] = approval
+	delete(redis.events, transfer.Hash)
+
+	events, err = manager.GetEventsByHashes(ctx, chainId, []common.Hash{transfer.Hash, approval.Hash})
+	tAssert.NoError(err)
+	tAssert.Same(partial, events[transfer.Hash])
+	tAssert.Same(approval, events[approval.Hash])
+	tAssert.Equal([][]common.Hash{{transfer.Hash}}, clickhouse.batches)
+}
+
+func TestManager_LookupCancelled(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	backend := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}, delay: time.Minute}
+
+	manager, err := NewManager(context.TODO(), WithReader("clickhouse", backend))
+	tAssert.NoError(err)
+
+	reader, err := NewCachingReader(manager)
+	tAssert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Lookup of the cancelled caller is not the miss, nothing is cached for the record.
+	_, err = reader.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.ErrorIs(err, context.Canceled)
+	tAssert.NotErrorIs(err, ErrRecordNotFound)
+	tAssert.Zero(reader.Stats().Size)
+
+	backend.delay = 0
+
+	event, err := reader.GetEventByHash(context.Background(), chainId, transfer.Hash)
+	tAssert.NoError(err)
+	tAssert.Same(transfer, event)
+	tAssert.Equal(2, backend.calls)
+}
@@ -1,17 +1,34 @@
 package readers
 
-import "context"
-
-// Manager is a struct that manages multiple Reader instances.
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Manager is a struct that manages multiple Reader instances. It implements Reader itself, looking
+// records up across its readers according to the configured Strategy.
 type Manager struct {
-	// ctx is the context in which the Manager operates.
-	ctx context.Context
+	// mu guards the readers and their settings, as readers can be added while lookups are served.
+	mu sync.RWMutex
 	// readers is a map that stores Reader instances by their names.
 	readers map[string]Reader
 	// priorityReader is the name of the Reader that has priority over others.
 	priorityReader string
+	// priorities stores the priority of the readers by their names. Readers without one have priority 0.
+	priorities map[string]int
+	// disabled stores the names of the readers that are skipped by the lookups.
+	disabled map[string]bool
+	// strategy defines how records are looked up across the readers.
+	strategy Strategy
+	// writeBack enables writing records served by slower readers into the faster ones that missed them.
+	writeBack bool
+	// hitHook is called with the name of the reader that served the record.
+	hitHook HitHook
 }
 
+var _ Reader = (*Manager)(nil)
+
 // ManagerOption is a function that applies a certain configuration to a Manager instance.
 type ManagerOption func(*Manager)
 
@@ -29,9 +46,40 @@ func WithReader(name string, reader Reader) ManagerOption {
 	}
 }
 
-// NewManager creates a new Manager instance with the provided context and options.
+// WithReaderPriority is a ManagerOption that sets the priority of the named reader. Readers with the higher
+// priority are asked first, readers with the same priority are ordered by their names.
+func WithReaderPriority(name string, priority int) ManagerOption {
+	return func(m *Manager) {
+		m.setPriority(name, priority)
+	}
+}
+
+// WithStrategy is a ManagerOption that sets the lookup strategy of a Manager. Defaults to StrategyFirstHit.
+func WithStrategy(strategy Strategy) ManagerOption {
+	return func(m *Manager) {
+		m.strategy = strategy
+	}
+}
+
+// WithWriteBack is a ManagerOption that enables read-through write-back: records found by the slower readers
+// (e.g. ClickHouse) are written into the faster readers implementing Writer (e.g. Redis) that missed them.
+func WithWriteBack(enabled bool) ManagerOption {
+	return func(m *Manager) {
+		m.writeBack = enabled
+	}
+}
+
+// WithHitHook is a ManagerOption that sets the function reporting which reader served each record.
+func WithHitHook(hook HitHook) ManagerOption {
+	return func(m *Manager) {
+		m.hitHook = hook
+	}
+}
+
+// NewManager creates a new Manager instance with the provided options. Context is not retained,
+// lookups run within the context of their callers.
 func NewManager(ctx context.Context, opts ...ManagerOption) (*Manager, error) {
-	manager := &Manager{ctx: ctx, readers: make(map[string]Reader)}
+	manager := &Manager{readers: make(map[string]Reader)}
 
 	for _, opt := range opts {
 		opt(manager)
@@ -40,13 +88,19 @@ func NewManager(ctx context.Context, opts ...ManagerOption) (*Manager, error) {
 	return manager, nil
 }
 
-// AddReader adds a new Reader to the Manager.
+// AddReader adds a new Reader to the Manager. It is safe to call while the Manager serves lookups.
 func (m *Manager) AddReader(name string, reader Reader) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.readers[name] = reader
 }
 
 // SetPriorityReader sets the priority reader of the Manager.
 func (m *Manager) SetPriorityReader(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if _, ok := m.readers[name]; !ok {
 		return ErrReaderNotFound
 	}
@@ -57,6 +111,9 @@ func (m *Manager) SetPriorityReader(name string) error {
 
 // GetPriorityReader returns the priority reader of the Manager.
 func (m *Manager) GetPriorityReader() Reader {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	if m.priorityReader == "" {
 		return nil
 	}
@@ -64,13 +121,75 @@ func (m *Manager) GetPriorityReader() Reader {
 	return m.readers[m.priorityReader]
 }
 
-// GetReaders returns all the readers of the Manager.
+// SetReaderPriority sets the priority of the named reader. Readers with the higher priority are asked first.
+func (m *Manager) SetReaderPriority(name string, priority int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.readers[name]; !ok {
+		return ErrReaderNotFound
+	}
+
+	m.setPriority(name, priority)
+	return nil
+}
+
+// EnableReader includes the previously disabled reader in the lookups again.
+func (m *Manager) EnableReader(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.readers[name]; !ok {
+		return ErrReaderNotFound
+	}
+
+	delete(m.disabled, name)
+	return nil
+}
+
+// DisableReader excludes the reader from the lookups without removing it from the Manager.
+func (m *Manager) DisableReader(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.readers[name]; !ok {
+		return ErrReaderNotFound
+	}
+
+	if m.disabled == nil {
+		m.disabled = make(map[string]bool)
+	}
+	m.disabled[name] = true
+	return nil
+}
+
+// IsReaderEnabled reports whether the reader exists and takes part in the lookups.
+func (m *Manager) IsReaderEnabled(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.readers[name]
+	return ok && !m.disabled[name]
+}
+
+// GetReaders returns a copy of all the readers of the Manager, including the disabled ones.
 func (m *Manager) GetReaders() map[string]Reader {
-	return m.readers
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	readers := make(map[string]Reader, len(m.readers))
+	for name, reader := range m.readers {
+		readers[name] = reader
+	}
+
+	return readers
 }
 
 // GetReaderByName returns a Reader by its name.
 func (m *Manager) GetReaderByName(name string) (Reader, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	if reader, ok := m.readers[name]; ok {
 		return reader, nil
 	}
@@ -78,21 +197,50 @@ func (m *Manager) GetReaderByName(name string) (Reader, error) {
 	return nil, ErrReaderNotFound
 }
 
-// GetSortedReaders returns all the readers of the Manager, with the priority reader being the first in the list.
+// GetSortedReaders returns the enabled readers of the Manager in the order the lookups ask them: the priority
+// reader first, followed by the rest by descending priority and then by name.
 func (m *Manager) GetSortedReaders() []Reader {
-	readers := make([]Reader, 0, len(m.readers))
+	sorted := m.sortedReaders()
 
-	// If a priority reader is set, add it to the slice first
-	if m.priorityReader != "" {
-		readers = append(readers, m.readers[m.priorityReader])
+	readers := make([]Reader, 0, len(sorted))
+	for _, r := range sorted {
+		readers = append(readers, r.reader)
 	}
 
-	// Add the rest of the readers to the slice
+	return readers
+}
+
+// sortedReaders returns the enabled readers together with their names in the order of GetSortedReaders.
+// The returned slice is a snapshot, so the lookups never hold the lock while the readers are queried.
+func (m *Manager) sortedReaders() []namedReader {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	readers := make([]namedReader, 0, len(m.readers))
 	for name, reader := range m.readers {
-		if name != m.priorityReader {
-			readers = append(readers, reader)
+		if !m.disabled[name] {
+			readers = append(readers, namedReader{name: name, reader: reader})
 		}
 	}
 
+	sort.Slice(readers, func(i, j int) bool {
+		a, b := readers[i].name, readers[j].name
+		if (a == m.priorityReader) != (b == m.priorityReader) {
+			return a == m.priorityReader
+		}
+		if m.priorities[a] != m.priorities[b] {
+			return m.priorities[a] > m.priorities[b]
+		}
+		return a < b
+	})
+
 	return readers
 }
+
+// setPriority stores the priority of the reader. The caller must hold the lock unless the Manager is being built.
+func (m *Manager) setPriority(name string, priority int) {
+	if m.priorities == nil {
+		m.priorities = make(map[string]int)
+	}
+	m.priorities[name] = priority
+}
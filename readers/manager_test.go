@@ -3,15 +3,16 @@ package readers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestManager_GetReaders(t *testing.T) {
-	ctx := context.TODO()
 	manager := &Manager{
-		ctx:     ctx,
 		readers: make(map[string]Reader),
 	}
 
@@ -48,10 +49,6 @@ func TestNewManager(t *testing.T) {
 		t.Errorf("Failed to create Manager: %s", err)
 	}
 
-	if manager.ctx != ctx {
-		t.Error("Context not set correctly")
-	}
-
 	if manager.priorityReader != "reader1" {
 		t.Error("Priority reader not set correctly")
 	}
@@ -66,9 +63,7 @@ func TestNewManager(t *testing.T) {
 }
 
 func TestManager_SetPriorityReader(t *testing.T) {
-	ctx := context.TODO()
 	manager := &Manager{
-		ctx:     ctx,
 		readers: make(map[string]Reader),
 	}
 
@@ -92,40 +87,26 @@ func TestManager_SetPriorityReader(t *testing.T) {
 }
 
 func TestManager_GetSortedReaders(t *testing.T) {
-	ctx := context.TODO()
+	tAssert := assert.New(t)
+
 	manager := &Manager{
-		ctx:     ctx,
 		readers: make(map[string]Reader),
 	}
 
-	reader1 := &MockReader{}
-	reader2 := &MockReader{}
-	reader3 := &MockReader{}
-
-	manager.AddReader("reader1", reader1)
-	manager.AddReader("reader2", reader2)
-	manager.AddReader("reader3", reader3)
+	manager.AddReader("reader1", &nameReader{name: "reader1"})
+	manager.AddReader("reader2", &nameReader{name: "reader2"})
+	manager.AddReader("reader3", &nameReader{name: "reader3"})
 
-	err := manager.SetPriorityReader("reader2")
-	assert.NoError(t, err)
+	tAssert.NoError(manager.SetPriorityReader("reader2"))
 
-	sortedReaders := manager.GetSortedReaders()
-	if len(sortedReaders) != 3 {
-		t.Errorf("Expected reader count: 3, got: %d", len(sortedReaders))
-	}
-
-	if sortedReaders[0] != reader2 {
-		t.Error("Priority reader not placed at the beginning")
-	}
-
-	if sortedReaders[1] != reader1 || sortedReaders[2] != reader3 {
-		t.Error("Readers not sorted correctly")
+	// Priority reader is placed at the beginning, the rest is ordered by the names regardless of the map order.
+	for i := 0; i < 10; i++ {
+		tAssert.Equal([]string{"reader2", "reader1", "reader3"}, readerNames(manager.GetSortedReaders()))
 	}
 }
 
 func TestManager_AddReader(t *testing.T) {
-	ctx := context.TODO()
-	manager := &Manager{ctx: ctx, readers: make(map[string]Reader)}
+	manager := &Manager{readers: make(map[string]Reader)}
 
 	reader := &MockReader{}
 	manager.AddReader("mock", reader)
@@ -147,9 +128,7 @@ func TestManager_AddReader(t *testing.T) {
 }
 
 func TestManager_GetReaderByName(t *testing.T) {
-	ctx := context.TODO()
 	manager := &Manager{
-		ctx:     ctx,
 		readers: make(map[string]Reader),
 	}
 
@@ -170,3 +149,99 @@ func TestManager_GetReaderByName(t *testing.T) {
 		t.Error("Expected ErrReaderNotFound error, but got different error or nil")
 	}
 }
+
+// nameReader stub is told apart by its name, unlike the zero-size MockReader whose instances all share the address.
+type nameReader struct {
+	MockReader
+	name string
+}
+
+// readerNames returns the names of the nameReader stubs in the order of the readers.
+func readerNames(readers []Reader) []string {
+	names := make([]string, 0, len(readers))
+	for _, reader := range readers {
+		names = append(names, reader.(*nameReader).name)
+	}
+	return names
+}
+
+func TestManager_ReaderPriorities(t *testing.T) {
+	tAssert := assert.New(t)
+
+	manager, err := NewManager(context.TODO(),
+		WithReader("sourcify", &nameReader{name: "sourcify"}),
+		WithReader("fourbyte", &nameReader{name: "fourbyte"}),
+		WithReader("clickhouse", &nameReader{name: "clickhouse"}),
+		WithReader("redis", &nameReader{name: "redis"}),
+		WithReaderPriority("redis", 100),
+		WithReaderPriority("clickhouse", 50),
+	)
+	tAssert.NoError(err)
+
+	// Readers with the same priority are ordered by their names.
+	for i := 0; i < 10; i++ {
+		tAssert.Equal([]string{"redis", "clickhouse", "fourbyte", "sourcify"}, readerNames(manager.GetSortedReaders()))
+	}
+
+	tAssert.NoError(manager.SetReaderPriority("sourcify", 75))
+	tAssert.Equal([]string{"redis", "sourcify", "clickhouse", "fourbyte"}, readerNames(manager.GetSortedReaders()))
+
+	// Priority reader stays first regardless of the priorities.
+	tAssert.NoError(manager.SetPriorityReader("fourbyte"))
+	tAssert.Equal([]string{"fourbyte", "redis", "sourcify", "clickhouse"}, readerNames(manager.GetSortedReaders()))
+
+	tAssert.ErrorIs(manager.SetReaderPriority("nonexistent", 1), ErrReaderNotFound)
+}
+
+func TestManager_DisableReader(t *testing.T) {
+	tAssert := assert.New(t)
+
+	manager, err := NewManager(context.TODO(),
+		WithReader("redis", &nameReader{name: "redis"}),
+		WithReader("clickhouse", &nameReader{name: "clickhouse"}),
+		WithPriorityReader("redis"),
+	)
+	tAssert.NoError(err)
+
+	tAssert.NoError(manager.DisableReader("redis"))
+	tAssert.False(manager.IsReaderEnabled("redis"))
+	tAssert.Equal([]string{"clickhouse"}, readerNames(manager.GetSortedReaders()))
+	tAssert.Len(manager.GetReaders(), 2)
+
+	tAssert.NoError(manager.EnableReader("redis"))
+	tAssert.True(manager.IsReaderEnabled("redis"))
+	tAssert.Equal([]string{"redis", "clickhouse"}, readerNames(manager.GetSortedReaders()))
+
+	tAssert.ErrorIs(manager.DisableReader("nonexistent"), ErrReaderNotFound)
+	tAssert.ErrorIs(manager.EnableReader("nonexistent"), ErrReaderNotFound)
+	tAssert.False(manager.IsReaderEnabled("nonexistent"))
+
+	tAssert.NoError(manager.DisableReader("redis"))
+	tAssert.NoError(manager.DisableReader("clickhouse"))
+	_, err = manager.GetEventByHash(context.TODO(), nil, common.Hash{})
+	tAssert.ErrorIs(err, ErrNoReaders)
+}
+
+func TestManager_ConcurrentAddReader(t *testing.T) {
+	manager, err := NewManager(context.TODO())
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			manager.AddReader(fmt.Sprintf("reader%d", i), &MockReader{})
+		}(i)
+		go func() {
+			defer wg.Done()
+			manager.GetSortedReaders()
+			_, _ = manager.GetEventByHash(context.TODO(), nil, common.Hash{})
+		}()
+	}
+	wg.Wait()
+
+	if len(manager.GetReaders()) != 20 {
+		t.Errorf("Expected reader count: 20, got: %d", len(manager.GetReaders()))
+	}
+}
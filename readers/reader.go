@@ -2,18 +2,36 @@
 package readers
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/txpull/unpack/types"
 )
 
+// Reader looks up contracts, methods, events and custom errors stored by the crawlers. Every lookup takes
+// the context so that it can be cancelled or timed out on its own. Batch lookups return only the records
+// that were found, missing keys are simply not present in the returned map.
 type Reader interface {
-	GetContractByAddress(chainId *big.Int, address common.Address) (*types.Contract, error)
+	GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error)
 
-	GetMethodBySignature(chainId *big.Int, signature string) (*types.Method, error)
+	// GetContractsByAddresses returns the contracts found for the addresses, keyed by the address.
+	GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error)
 
-	GetEventByHash(chainId *big.Int, hash common.Hash) (*types.Event, error)
+	GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error)
+
+	// GetMethodsBySignatures returns the methods found for the hex encoded selectors, keyed by the selector.
+	GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error)
+
+	// GetMethodCandidates returns all of the methods sharing the hex encoded selector.
+	GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error)
+
+	GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error)
+
+	// GetEventsByHashes returns the events found for the hashes, keyed by the hash.
+	GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error)
+
+	GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error)
 
 	// String returns the name of the Reader.
 	String() string
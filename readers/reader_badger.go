@@ -0,0 +1,240 @@
+package readers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/types"
+)
+
+// BadgerReader reads the records stored by the crawlers into the embedded BadgerDB, using the same keys as
+// RedisReader. It allows decoding with the on-disk signature database and no external services.
+type BadgerReader struct {
+	ctx context.Context
+	db  *db.BadgerDB
+}
+
+var (
+	_ Reader = (*BadgerReader)(nil)
+	_ Writer = (*BadgerReader)(nil)
+)
+
+func NewBadgerReader(ctx context.Context, bdb *db.BadgerDB) (*BadgerReader, error) {
+	return &BadgerReader{
+		ctx: ctx,
+		db:  bdb,
+	}, nil
+}
+
+func (r *BadgerReader) GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error) {
+	contract := &types.Contract{}
+	if err := r.getRecord(ctx, types.GetContractStorageKey(chainId, address), contract.UnmarshalBytes); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (r *BadgerReader) GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+	toReturn := make(map[common.Address]*types.Contract, len(addresses))
+	for _, address := range addresses {
+		contract, err := r.GetContractByAddress(ctx, chainId, address)
+		if errors.Is(err, ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		toReturn[address] = contract
+	}
+
+	return toReturn, nil
+}
+
+func (r *BadgerReader) GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error) {
+	method := &types.Method{}
+	if err := r.getRecord(ctx, types.GetMethodStorageKey(chainId, common.FromHex(signature)), method.UnmarshalBytes); err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+func (r *BadgerReader) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	toReturn := make(map[string]*types.Method, len(signatures))
+	for _, signature := range signatures {
+		method, err := r.GetMethodBySignature(ctx, chainId, signature)
+		if errors.Is(err, ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		toReturn[signature] = method
+	}
+
+	return toReturn, nil
+}
+
+func (r *BadgerReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	var candidates types.Methods
+	err := r.getRecord(ctx, types.GetMethodCandidatesStorageKey(chainId, common.FromHex(selector)), candidates.UnmarshalBytes)
+	if errors.Is(err, ErrRecordNotFound) {
+		// Selectors written before candidates were tracked only have the single method stored.
+		method, methodErr := r.GetMethodBySignature(ctx, chainId, selector)
+		if methodErr != nil {
+			return nil, methodErr
+		}
+		return types.Methods{method}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// GetMethods returns all of the methods stored for the chain, iterating over the method keys prefix.
+func (r *BadgerReader) GetMethods(ctx context.Context, chainId *big.Int) (types.Methods, error) {
+	var methods types.Methods
+	err := r.iterate(ctx, chainPrefix(types.GetMethodStorageKeyPrefix(), chainId), func(value []byte) error {
+		method := &types.Method{}
+		if err := method.UnmarshalBytes(value); err != nil {
+			return err
+		}
+		methods = append(methods, method)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return methods, nil
+}
+
+func (r *BadgerReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	event := &types.Event{}
+	if err := r.getRecord(ctx, types.GetEventStorageKey(chainId, hash), event.UnmarshalBytes); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (r *BadgerReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	toReturn := make(map[common.Hash]*types.Event, len(hashes))
+	for _, hash := range hashes {
+		event, err := r.GetEventByHash(ctx, chainId, hash)
+		if errors.Is(err, ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		toReturn[hash] = event
+	}
+
+	return toReturn, nil
+}
+
+// GetEvents returns all of the events stored for the chain, iterating over the event keys prefix.
+func (r *BadgerReader) GetEvents(ctx context.Context, chainId *big.Int) ([]*types.Event, error) {
+	var events []*types.Event
+	err := r.iterate(ctx, chainPrefix(types.GetEventStorageKeyPrefix(), chainId), func(value []byte) error {
+		event := &types.Event{}
+		if err := event.UnmarshalBytes(value); err != nil {
+			return err
+		}
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *BadgerReader) GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error) {
+	customError := &types.Error{}
+	if err := r.getRecord(ctx, types.GetErrorStorageKey(chainId, common.FromHex(signature)), customError.UnmarshalBytes); err != nil {
+		return nil, err
+	}
+
+	return customError, nil
+}
+
+// WriteContract stores the contract so that the following lookups are served from BadgerDB.
+func (r *BadgerReader) WriteContract(ctx context.Context, chainId *big.Int, contract *types.Contract) error {
+	return r.writeRecord(ctx, types.GetContractStorageKey(chainId, contract.Address), contract.MarshalBytes)
+}
+
+// WriteMethod stores the method so that the following lookups are served from BadgerDB.
+func (r *BadgerReader) WriteMethod(ctx context.Context, chainId *big.Int, method *types.Method) error {
+	return r.writeRecord(ctx, types.GetMethodStorageKey(chainId, method.Bytes), method.MarshalBytes)
+}
+
+// WriteEvent stores the event so that the following lookups are served from BadgerDB.
+func (r *BadgerReader) WriteEvent(ctx context.Context, chainId *big.Int, event *types.Event) error {
+	return r.writeRecord(ctx, types.GetEventStorageKey(chainId, event.Hash), event.MarshalBytes)
+}
+
+// WriteError stores the custom error so that the following lookups are served from BadgerDB.
+func (r *BadgerReader) WriteError(ctx context.Context, chainId *big.Int, customError *types.Error) error {
+	return r.writeRecord(ctx, types.GetErrorStorageKey(chainId, customError.Bytes), customError.MarshalBytes)
+}
+
+func (r *BadgerReader) String() string {
+	return "badger"
+}
+
+// getRecord retrieves the value of the key and unmarshals it, replacing the missing key error with ErrRecordNotFound.
+func (r *BadgerReader) getRecord(ctx context.Context, key string, unmarshal func([]byte) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	value, err := r.db.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return ErrRecordNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return unmarshal(value)
+}
+
+// writeRecord marshals the record and stores it under the key.
+func (r *BadgerReader) writeRecord(ctx context.Context, key string, marshal func() ([]byte, error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	value, err := marshal()
+	if err != nil {
+		return err
+	}
+
+	return r.db.Write(key, value)
+}
+
+// iterate calls fn with the value of every key starting with the prefix, stopping once the context is done.
+func (r *BadgerReader) iterate(ctx context.Context, prefix string, fn func(value []byte) error) error {
+	return r.db.Iterate(prefix, func(key string, value []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(value)
+	})
+}
+
+// chainPrefix returns the part of the storage key format shared by all of the keys of the chain.
+func chainPrefix(format string, chainId *big.Int) string {
+	return fmt.Sprintf(format, chainId.String(), "")
+}
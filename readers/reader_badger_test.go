@@ -0,0 +1,86 @@
+package readers
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/db"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/types"
+)
+
+func TestBadgerReader(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	bdb, err := db.NewBadgerDB(db.WithContext(ctx), db.WithDbPath(t.TempDir()))
+	tAssert.NoError(err)
+	defer bdb.Close()
+
+	reader, err := NewBadgerReader(context.TODO(), bdb)
+	tAssert.NoError(err)
+
+	transfer := &types.Method{Name: "transfer", Signature: "transfer(address,uint256)", Bytes: common.FromHex("0xa9059cbb")}
+	approve := &types.Method{Name: "approve", Signature: "approve(address,uint256)", Bytes: common.FromHex("0x095ea7b3")}
+	event := &types.Event{Name: "Transfer", Signature: "Transfer(address,address,uint256)", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	contract := &types.Contract{Address: common.HexToAddress("0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"), Name: "WBNB"}
+
+	tAssert.NoError(reader.WriteMethod(ctx, chainId, transfer))
+	tAssert.NoError(reader.WriteMethod(ctx, chainId, approve))
+	tAssert.NoError(reader.WriteMethod(ctx, big.NewInt(1), approve))
+	tAssert.NoError(reader.WriteEvent(ctx, chainId, event))
+	tAssert.NoError(reader.WriteContract(ctx, chainId, contract))
+
+	method, err := reader.GetMethodBySignature(ctx, chainId, "a9059cbb")
+	tAssert.NoError(err)
+	tAssert.Equal(transfer.Signature, method.Signature)
+
+	_, err = reader.GetMethodBySignature(ctx, chainId, "0x12345678")
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+
+	methods, err := reader.GetMethodsBySignatures(ctx, chainId, []string{"a9059cbb", "12345678"})
+	tAssert.NoError(err)
+	tAssert.Len(methods, 1)
+
+	found, err := reader.GetContractByAddress(ctx, chainId, contract.Address)
+	tAssert.NoError(err)
+	tAssert.Equal("WBNB", found.Name)
+
+	events, err := reader.GetEventsByHashes(ctx, chainId, []common.Hash{event.Hash, common.HexToHash("0x01")})
+	tAssert.NoError(err)
+	tAssert.Len(events, 1)
+	tAssert.Equal(event.Signature, events[event.Hash].Signature)
+
+	// Prefix iteration lists only the records of the requested chain.
+	all, err := reader.GetMethods(ctx, chainId)
+	tAssert.NoError(err)
+	tAssert.Len(all, 2)
+
+	allEvents, err := reader.GetEvents(ctx, chainId)
+	tAssert.NoError(err)
+	tAssert.Len(allEvents, 1)
+
+	// Candidates written by the crawlers through the Badger sink are served by the reader.
+	store := db.NewBadgerStore(bdb)
+	collision := &types.Method{Name: "many_msg_babbage", Signature: "many_msg_babbage(bytes1)", Bytes: transfer.Bytes}
+	for _, m := range []*types.Method{transfer, collision} {
+		_, err := helpers.AppendMethodCandidate(ctx, store, chainId, m)
+		tAssert.NoError(err)
+	}
+
+	candidates, err := reader.GetMethodCandidates(ctx, chainId, "0xa9059cbb")
+	tAssert.NoError(err)
+	tAssert.Len(candidates, 2)
+
+	candidates, err = reader.GetMethodCandidates(ctx, chainId, "0x095ea7b3")
+	tAssert.NoError(err)
+	tAssert.Len(candidates, 1)
+
+	_, err = reader.GetMethodCandidates(ctx, chainId, "0x12345678")
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+}
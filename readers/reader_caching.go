@@ -0,0 +1,475 @@
+package readers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/txpull/unpack/types"
+)
+
+const (
+	// DefaultCacheSize is the default maximum number of records kept by the CachingReader.
+	DefaultCacheSize = 10000
+
+	// DefaultCacheTTL is the default time the found records are kept by the CachingReader.
+	DefaultCacheTTL = 10 * time.Minute
+
+	// DefaultNegativeCacheTTL is the default time the misses are kept by the CachingReader.
+	DefaultNegativeCacheTTL = time.Minute
+)
+
+// CacheStats is the snapshot of the CachingReader counters.
+type CacheStats struct {
+	// Hits is the number of lookups served from the cache, including the cached misses.
+	Hits uint64
+	// NegativeHits is the number of lookups answered with the cached miss.
+	NegativeHits uint64
+	// Misses is the number of lookups passed to the wrapped reader or waiting for a concurrent one.
+	Misses uint64
+	// Coalesced is the number of misses that shared the lookup of a concurrent caller instead of their own.
+	Coalesced uint64
+	// Evictions is the number of records evicted to keep the cache within its size.
+	Evictions uint64
+	// Size is the number of records currently in the cache.
+	Size int
+}
+
+// CachingReader wraps any Reader with a bounded in-memory LRU cache. Found records are kept for the TTL
+// and misses for the negative TTL, so hot selectors are not looked up in Redis or ClickHouse on every decode.
+// Concurrent lookups of the same key are coalesced into a single lookup of the wrapped reader.
+type CachingReader struct {
+	// reader is the wrapped Reader.
+	reader Reader
+	// size is the maximum number of records kept in the cache.
+	size int
+	// ttl is the time the found records are kept, zero keeps them until evicted.
+	ttl time.Duration
+	// negativeTTL is the time the misses are kept, zero disables the negative caching.
+	negativeTTL time.Duration
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+
+	// mu guards the cache.
+	mu    sync.Mutex
+	cache lru.BasicLRU[string, cacheEntry]
+
+	// flightsMu guards the lookups in progress.
+	flightsMu sync.Mutex
+	flights   map[string]*flight
+
+	hits         atomic.Uint64
+	negativeHits atomic.Uint64
+	misses       atomic.Uint64
+	coalesced    atomic.Uint64
+	evictions    atomic.Uint64
+}
+
+var (
+	_ Reader = (*CachingReader)(nil)
+	_ Writer = (*CachingReader)(nil)
+)
+
+// cacheEntry is the cached record, or the cached miss if the err is set.
+type cacheEntry struct {
+	value     any
+	err       error
+	expiresAt time.Time
+}
+
+// flight is the lookup of a single key in progress, shared by the concurrent callers.
+type flight struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// CachingOption is a function that applies a certain configuration to a CachingReader instance.
+type CachingOption func(*CachingReader)
+
+// WithCacheSize is a CachingOption that sets the maximum number of records kept in the cache.
+func WithCacheSize(size int) CachingOption {
+	return func(r *CachingReader) {
+		r.size = size
+	}
+}
+
+// WithCacheTTL is a CachingOption that sets the time the found records are kept. Zero keeps them until evicted.
+func WithCacheTTL(ttl time.Duration) CachingOption {
+	return func(r *CachingReader) {
+		r.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL is a CachingOption that sets the time the misses are kept. Zero disables negative caching.
+func WithNegativeCacheTTL(ttl time.Duration) CachingOption {
+	return func(r *CachingReader) {
+		r.negativeTTL = ttl
+	}
+}
+
+// NewCachingReader creates a new CachingReader wrapping the reader with the provided options.
+func NewCachingReader(reader Reader, opts ...CachingOption) (*CachingReader, error) {
+	cachingReader := &CachingReader{
+		reader:      reader,
+		size:        DefaultCacheSize,
+		ttl:         DefaultCacheTTL,
+		negativeTTL: DefaultNegativeCacheTTL,
+		now:         time.Now,
+		flights:     make(map[string]*flight),
+	}
+
+	for _, opt := range opts {
+		opt(cachingReader)
+	}
+
+	if cachingReader.size <= 0 {
+		return nil, ErrInvalidCacheSize
+	}
+
+	cachingReader.cache = lru.NewBasicLRU[string, cacheEntry](cachingReader.size)
+
+	return cachingReader, nil
+}
+
+func (r *CachingReader) GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error) {
+	return cached(ctx, r, contractCacheKey(chainId, address),
+		func(ctx context.Context) (*types.Contract, error) {
+			return r.reader.GetContractByAddress(ctx, chainId, address)
+		},
+		func(contract *types.Contract) bool { return contract != nil },
+	)
+}
+
+func (r *CachingReader) GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+	return cachedBatch(ctx, r, addresses,
+		func(address common.Address) string { return contractCacheKey(chainId, address) },
+		func(ctx context.Context, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+			return r.reader.GetContractsByAddresses(ctx, chainId, addresses)
+		},
+	)
+}
+
+func (r *CachingReader) GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error) {
+	return cached(ctx, r, methodCacheKey(chainId, signature),
+		func(ctx context.Context) (*types.Method, error) {
+			return r.reader.GetMethodBySignature(ctx, chainId, signature)
+		},
+		func(method *types.Method) bool { return method != nil },
+	)
+}
+
+func (r *CachingReader) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	return cachedBatch(ctx, r, signatures,
+		func(signature string) string { return methodCacheKey(chainId, signature) },
+		func(ctx context.Context, signatures []string) (map[string]*types.Method, error) {
+			return r.reader.GetMethodsBySignatures(ctx, chainId, signatures)
+		},
+	)
+}
+
+func (r *CachingReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	return cached(ctx, r, candidatesCacheKey(chainId, selector),
+		func(ctx context.Context) (types.Methods, error) {
+			return r.reader.GetMethodCandidates(ctx, chainId, selector)
+		},
+		func(methods types.Methods) bool { return len(methods) > 0 },
+	)
+}
+
+func (r *CachingReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	return cached(ctx, r, eventCacheKey(chainId, hash),
+		func(ctx context.Context) (*types.Event, error) {
+			return r.reader.GetEventByHash(ctx, chainId, hash)
+		},
+		func(event *types.Event) bool { return event != nil },
+	)
+}
+
+func (r *CachingReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	return cachedBatch(ctx, r, hashes,
+		func(hash common.Hash) string { return eventCacheKey(chainId, hash) },
+		func(ctx context.Context, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+			return r.reader.GetEventsByHashes(ctx, chainId, hashes)
+		},
+	)
+}
+
+func (r *CachingReader) GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error) {
+	return cached(ctx, r, errorCacheKey(chainId, signature),
+		func(ctx context.Context) (*types.Error, error) {
+			return r.reader.GetErrorBySignature(ctx, chainId, signature)
+		},
+		func(customError *types.Error) bool { return customError != nil },
+	)
+}
+
+// WriteContract caches the contract and writes it into the wrapped reader if it implements Writer.
+func (r *CachingReader) WriteContract(ctx context.Context, chainId *big.Int, contract *types.Contract) error {
+	r.store(contractCacheKey(chainId, contract.Address), contract, nil)
+	if w, ok := r.reader.(Writer); ok {
+		return w.WriteContract(ctx, chainId, contract)
+	}
+	return nil
+}
+
+// WriteMethod caches the method and writes it into the wrapped reader if it implements Writer.
+// Cached candidates of the method selector, including the cached miss, are dropped as they are stale now.
+func (r *CachingReader) WriteMethod(ctx context.Context, chainId *big.Int, method *types.Method) error {
+	selector := common.Bytes2Hex(method.Bytes)
+	r.store(methodCacheKey(chainId, selector), method, nil)
+	r.remove(candidatesCacheKey(chainId, selector))
+	if w, ok := r.reader.(Writer); ok {
+		return w.WriteMethod(ctx, chainId, method)
+	}
+	return nil
+}
+
+// WriteEvent caches the event and writes it into the wrapped reader if it implements Writer.
+func (r *CachingReader) WriteEvent(ctx context.Context, chainId *big.Int, event *types.Event) error {
+	r.store(eventCacheKey(chainId, event.Hash), event, nil)
+	if w, ok := r.reader.(Writer); ok {
+		return w.WriteEvent(ctx, chainId, event)
+	}
+	return nil
+}
+
+// WriteError caches the custom error and writes it into the wrapped reader if it implements Writer.
+func (r *CachingReader) WriteError(ctx context.Context, chainId *big.Int, customError *types.Error) error {
+	r.store(errorCacheKey(chainId, common.Bytes2Hex(customError.Bytes)), customError, nil)
+	if w, ok := r.reader.(Writer); ok {
+		return w.WriteError(ctx, chainId, customError)
+	}
+	return nil
+}
+
+// Stats returns the snapshot of the cache counters.
+func (r *CachingReader) Stats() CacheStats {
+	r.mu.Lock()
+	size := r.cache.Len()
+	r.mu.Unlock()
+
+	return CacheStats{
+		Hits:         r.hits.Load(),
+		NegativeHits: r.negativeHits.Load(),
+		Misses:       r.misses.Load(),
+		Coalesced:    r.coalesced.Load(),
+		Evictions:    r.evictions.Load(),
+		Size:         size,
+	}
+}
+
+// Purge removes all of the records from the cache.
+func (r *CachingReader) Purge() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache.Purge()
+}
+
+// String returns the name of the CachingReader, which is the name of the wrapped reader.
+func (r *CachingReader) String() string {
+	return "cache(" + r.reader.String() + ")"
+}
+
+// load returns the cached record or miss of the key, dropping it if it has expired.
+func (r *CachingReader) load(key string) (cacheEntry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.cache.Get(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+
+	if !entry.expiresAt.IsZero() && !r.now().Before(entry.expiresAt) {
+		r.cache.Remove(key)
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// store caches the record, or the miss if the err is ErrRecordNotFound. Other errors are never cached.
+func (r *CachingReader) store(key string, value any, err error) {
+	ttl := r.ttl
+	if err != nil {
+		if !errors.Is(err, ErrRecordNotFound) || r.negativeTTL <= 0 {
+			return
+		}
+		value, err, ttl = nil, ErrRecordNotFound, r.negativeTTL
+	}
+
+	entry := cacheEntry{value: value, err: err}
+	if ttl > 0 {
+		entry.expiresAt = r.now().Add(ttl)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if evicted := r.cache.Add(key, entry); evicted {
+		r.evictions.Add(1)
+	}
+}
+
+// remove drops the cached record or miss of the key.
+func (r *CachingReader) remove(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache.Remove(key)
+}
+
+// cached serves the record of the key from the cache. On the cache miss the record is looked up using get,
+// concurrent callers of the same key wait for the single lookup in progress instead of starting their own.
+// The lookup runs on the context of the caller that started it, callers waiting for it retry the lookup
+// if it fails only because that context was cancelled or timed out.
+// Records that are not found are reported with ErrRecordNotFound.
+func cached[T any](ctx context.Context, r *CachingReader, key string, get func(context.Context) (T, error), found func(T) bool) (T, error) {
+	var empty T
+
+	if entry, ok := r.load(key); ok {
+		r.hits.Add(1)
+		if entry.err != nil {
+			r.negativeHits.Add(1)
+			return empty, entry.err
+		}
+		return entry.value.(T), nil
+	}
+
+	r.misses.Add(1)
+
+	for {
+		r.flightsMu.Lock()
+		f, ok := r.flights[key]
+		if !ok {
+			break
+		}
+		r.flightsMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return empty, ctx.Err()
+		case <-f.done:
+		}
+
+		// Cancellation or deadline of the caller leading the lookup is not the outcome of the lookup itself,
+		// the lookup is retried (joining the next one in progress or leading it) rather than sharing the error.
+		if errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded) {
+			continue
+		}
+
+		r.coalesced.Add(1)
+		if f.err != nil {
+			return empty, f.err
+		}
+		return f.value.(T), nil
+	}
+
+	f := &flight{done: make(chan struct{})}
+	r.flights[key] = f
+	r.flightsMu.Unlock()
+
+	defer func() {
+		r.flightsMu.Lock()
+		delete(r.flights, key)
+		r.flightsMu.Unlock()
+		close(f.done)
+	}()
+
+	value, err := get(ctx)
+	if err == nil && !found(value) {
+		err = ErrRecordNotFound
+	}
+
+	f.value, f.err = value, err
+	r.store(key, value, err)
+
+	if err != nil {
+		return empty, err
+	}
+
+	return value, nil
+}
+
+// cachedBatch serves the cached records of the keys and looks the rest up with a single batch lookup.
+// Keys missing from the batch lookup result are cached as misses.
+func cachedBatch[K comparable, T any](
+	ctx context.Context,
+	r *CachingReader,
+	keys []K,
+	cacheKey func(K) string,
+	get func(context.Context, []K) (map[K]T, error),
+) (map[K]T, error) {
+	toReturn := make(map[K]T, len(keys))
+
+	missing := make([]K, 0, len(keys))
+	for _, key := range keys {
+		entry, ok := r.load(cacheKey(key))
+		if !ok {
+			r.misses.Add(1)
+			missing = append(missing, key)
+			continue
+		}
+
+		r.hits.Add(1)
+		if entry.err != nil {
+			r.negativeHits.Add(1)
+			continue
+		}
+		toReturn[key] = entry.value.(T)
+	}
+
+	if len(missing) == 0 {
+		return toReturn, nil
+	}
+
+	records, err := get(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range missing {
+		record, ok := records[key]
+		if ok {
+			toReturn[key] = record
+			r.store(cacheKey(key), record, nil)
+			continue
+		}
+		r.store(cacheKey(key), nil, ErrRecordNotFound)
+	}
+
+	return toReturn, nil
+}
+
+func contractCacheKey(chainId *big.Int, address common.Address) string {
+	return fmt.Sprintf("contract:%s:%s", chainId, address.Hex())
+}
+
+func methodCacheKey(chainId *big.Int, signature string) string {
+	return fmt.Sprintf("method:%s:%s", chainId, normalizeHex(signature))
+}
+
+func candidatesCacheKey(chainId *big.Int, selector string) string {
+	return fmt.Sprintf("candidates:%s:%s", chainId, normalizeHex(selector))
+}
+
+func eventCacheKey(chainId *big.Int, hash common.Hash) string {
+	return fmt.Sprintf("event:%s:%s", chainId, hash.Hex())
+}
+
+func errorCacheKey(chainId *big.Int, signature string) string {
+	return fmt.Sprintf("error:%s:%s", chainId, normalizeHex(signature))
+}
+
+// normalizeHex returns the lowercase hex without the 0x prefix, so that the same selector is cached once.
+func normalizeHex(value string) string {
+	return common.Bytes2Hex(common.FromHex(value))
+}
@@ -0,0 +1,303 @@
+package readers
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/types"
+)
+
+func TestCachingReader(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	unknown := common.HexToHash("0x01")
+
+	backend := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}}
+
+	reader, err := NewCachingReader(backend, WithCacheTTL(time.Minute), WithNegativeCacheTTL(time.Second))
+	tAssert.NoError(err)
+
+	now := time.Now()
+	reader.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		event, err := reader.GetEventByHash(ctx, chainId, transfer.Hash)
+		tAssert.NoError(err)
+		tAssert.Equal(transfer, event)
+	}
+	tAssert.Equal(1, backend.calls)
+
+	// Misses are cached for the negative TTL only.
+	for i := 0; i < 3; i++ {
+		_, err = reader.GetEventByHash(ctx, chainId, unknown)
+		tAssert.ErrorIs(err, ErrRecordNotFound)
+	}
+	tAssert.Equal(2, backend.calls)
+
+	now = now.Add(2 * time.Second)
+	_, err = reader.GetEventByHash(ctx, chainId, unknown)
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+	tAssert.Equal(3, backend.calls)
+
+	now = now.Add(time.Minute)
+	_, err = reader.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(4, backend.calls)
+
+	tAssert.Equal(CacheStats{Hits: 4, NegativeHits: 2, Misses: 4, Size: 2}, reader.Stats())
+
+	// Backend failures are never cached.
+	backend.err = assert.AnError
+	_, err = reader.GetEventByHash(ctx, chainId, common.HexToHash("0x02"))
+	tAssert.ErrorIs(err, assert.AnError)
+	_, err = reader.GetEventByHash(ctx, chainId, common.HexToHash("0x02"))
+	tAssert.ErrorIs(err, assert.AnError)
+	tAssert.Equal(6, backend.calls)
+
+	_, err = NewCachingReader(backend, WithCacheSize(0))
+	tAssert.ErrorIs(err, ErrInvalidCacheSize)
+}
+
+func TestCachingReader_Eviction(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	backend := &eventReader{}
+
+	reader, err := NewCachingReader(backend, WithCacheSize(2))
+	tAssert.NoError(err)
+
+	for _, hash := range []string{"0x01", "0x02", "0x03", "0x01"} {
+		_, err = reader.GetEventByHash(ctx, chainId, common.HexToHash(hash))
+		tAssert.ErrorIs(err, ErrRecordNotFound)
+	}
+
+	stats := reader.Stats()
+	tAssert.Equal(2, stats.Size)
+	tAssert.Equal(uint64(2), stats.Evictions)
+	tAssert.Equal(4, backend.calls)
+
+	// Negative caching can be disabled.
+	reader, err = NewCachingReader(backend, WithNegativeCacheTTL(0))
+	tAssert.NoError(err)
+
+	for i := 0; i < 2; i++ {
+		_, err = reader.GetEventByHash(ctx, chainId, common.HexToHash("0x01"))
+		tAssert.ErrorIs(err, ErrRecordNotFound)
+	}
+	tAssert.Equal(6, backend.calls)
+	tAssert.Equal(0, reader.Stats().Size)
+}
+
+func TestCachingReader_Coalescing(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	backend := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}, delay: 100 * time.Millisecond}
+
+	reader, err := NewCachingReader(backend)
+	tAssert.NoError(err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			event, err := reader.GetEventByHash(ctx, chainId, transfer.Hash)
+			tAssert.NoError(err)
+			tAssert.Equal(transfer, event)
+		}()
+	}
+	wg.Wait()
+
+	stats := reader.Stats()
+	tAssert.Equal(1, backend.calls)
+	tAssert.Equal(stats.Misses-1, stats.Coalesced)
+	tAssert.Equal(uint64(10), stats.Hits+stats.Misses)
+}
+
+func TestCachingReader_Batch(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	approval := &types.Event{Name: "Approval", Hash: common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")}
+	unknown := common.HexToHash("0x01")
+
+	backend := &eventReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer, approval.Hash: approval}}
+
+	reader, err := NewCachingReader(backend)
+	tAssert.NoError(err)
+
+	_, err = reader.GetEventByHash(ctx, chainId, transfer.Hash)
+	tAssert.NoError(err)
+
+	events, err := reader.GetEventsByHashes(ctx, chainId, []common.Hash{transfer.Hash, approval.Hash, unknown})
+	tAssert.NoError(err)
+	tAssert.Len(events, 2)
+
+	// Batch results, including the misses, are served by the single record lookups from the cache.
+	backend.err = assert.AnError
+	event, err := reader.GetEventByHash(ctx, chainId, approval.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(approval, event)
+	_, err = reader.GetEventByHash(ctx, chainId, unknown)
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+
+	events, err = reader.GetEventsByHashes(ctx, chainId, []common.Hash{transfer.Hash, approval.Hash, unknown})
+	tAssert.NoError(err)
+	tAssert.Len(events, 2)
+
+	// Written records are cached and passed to the wrapped writer.
+	deposit := &types.Event{Name: "Deposit", Hash: common.HexToHash("0xe1fffcc4923d04b559f4d29a8bfc6cda04eb5b0d3c460751c2402c5c5cc9109c")}
+	tAssert.NoError(reader.WriteEvent(ctx, chainId, deposit))
+	tAssert.Equal([]common.Hash{deposit.Hash}, backend.written)
+
+	event, err = reader.GetEventByHash(ctx, chainId, deposit.Hash)
+	tAssert.NoError(err)
+	tAssert.Equal(deposit, event)
+}
+
+// blockingReader blocks the first lookup until its context is done, announcing the lookup on started.
+// Later lookups serve the event right away.
+type blockingReader struct {
+	MockReader
+	event   *types.Event
+	started chan struct{}
+	mu      sync.Mutex
+	calls   int
+}
+
+func (r *blockingReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	r.mu.Lock()
+	r.calls++
+	first := r.calls == 1
+	r.mu.Unlock()
+
+	if first {
+		close(r.started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	return r.event, nil
+}
+
+// joinedContext announces the first wait on its Done channel, which is the follower joining the lookup in progress.
+type joinedContext struct {
+	context.Context
+	once   sync.Once
+	joined chan struct{}
+}
+
+func (c *joinedContext) Done() <-chan struct{} {
+	c.once.Do(func() { close(c.joined) })
+	return c.Context.Done()
+}
+
+func TestCachingReader_CoalescingLeaderCancelled(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+
+	transfer := &types.Event{Name: "Transfer", Hash: common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")}
+	backend := &blockingReader{event: transfer, started: make(chan struct{})}
+
+	reader, err := NewCachingReader(backend)
+	tAssert.NoError(err)
+
+	type outcome struct {
+		event *types.Event
+		err   error
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader := make(chan outcome, 1)
+	go func() {
+		event, err := reader.GetEventByHash(leaderCtx, chainId, transfer.Hash)
+		leader <- outcome{event: event, err: err}
+	}()
+	<-backend.started
+
+	// Follower joins the lookup in progress, which is then cancelled by its leader.
+	followerCtx := &joinedContext{Context: context.Background(), joined: make(chan struct{})}
+	follower := make(chan outcome, 1)
+	go func() {
+		event, err := reader.GetEventByHash(followerCtx, chainId, transfer.Hash)
+		follower <- outcome{event: event, err: err}
+	}()
+	<-followerCtx.joined
+
+	cancel()
+
+	leaderOutcome := <-leader
+	tAssert.ErrorIs(leaderOutcome.err, context.Canceled)
+	tAssert.Nil(leaderOutcome.event)
+
+	// Follower retries the lookup instead of sharing the cancellation of its leader.
+	followerOutcome := <-follower
+	tAssert.NoError(followerOutcome.err)
+	tAssert.Same(transfer, followerOutcome.event)
+	tAssert.Equal(2, backend.calls)
+	tAssert.Zero(reader.Stats().Coalesced)
+}
+
+// candidatesReader serves the method candidates and counts the lookups.
+type candidatesReader struct {
+	MockReader
+	candidates types.Methods
+	calls      int
+}
+
+func (r *candidatesReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	r.calls++
+	return r.candidates, nil
+}
+
+func TestCachingReader_WriteMethodInvalidatesCandidates(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+	chainId := big.NewInt(56)
+
+	backend := &candidatesReader{}
+
+	reader, err := NewCachingReader(backend)
+	tAssert.NoError(err)
+
+	// Miss is cached, the backend is not asked again.
+	_, err = reader.GetMethodCandidates(ctx, chainId, "0xa9059cbb")
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+	_, err = reader.GetMethodCandidates(ctx, chainId, "a9059cbb")
+	tAssert.ErrorIs(err, ErrRecordNotFound)
+	tAssert.Equal(1, backend.calls)
+
+	method, err := types.NewFourByteMethod("0xa9059cbb", "transfer(address,uint256)")
+	tAssert.NoError(err)
+	backend.candidates = types.Methods{method}
+
+	tAssert.NoError(reader.WriteMethod(ctx, chainId, method))
+
+	candidates, err := reader.GetMethodCandidates(ctx, chainId, "0xa9059cbb")
+	tAssert.NoError(err)
+	tAssert.Equal(types.Methods{method}, candidates)
+	tAssert.Equal(2, backend.calls)
+}
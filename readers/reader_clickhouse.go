@@ -2,6 +2,8 @@ package readers
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -22,18 +24,55 @@ func NewClickHouseReader(ctx context.Context, client *db.ClickHouse) (Reader, er
 	}, nil
 }
 
-func (r *ClickHouseReader) GetContractByAddress(chainId *big.Int, address common.Address) (*types.Contract, error) {
-	return models.GetContract(r.ctx, r.client, chainId, address)
+func (r *ClickHouseReader) GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error) {
+	return notFound(models.GetContract(ctx, r.client, chainId, address))
 }
 
-func (r *ClickHouseReader) GetMethodBySignature(chainId *big.Int, signature string) (*types.Method, error) {
-	return models.GetMethod(r.ctx, r.client, signature)
+func (r *ClickHouseReader) GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+	return models.GetContractsByAddresses(ctx, r.client, chainId, addresses)
 }
 
-func (r *ClickHouseReader) GetEventByHash(chainId *big.Int, hash common.Hash) (*types.Event, error) {
-	return models.GetEvent(r.ctx, r.client, chainId, hash)
+func (r *ClickHouseReader) GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error) {
+	return notFound(models.GetMethod(ctx, r.client, signature))
+}
+
+func (r *ClickHouseReader) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	return models.GetMethodsBySelectors(ctx, r.client, signatures)
+}
+
+func (r *ClickHouseReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	methods, err := models.GetMethods(ctx, r.client, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(methods) == 0 {
+		return nil, ErrRecordNotFound
+	}
+
+	return methods, nil
+}
+
+func (r *ClickHouseReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	return notFound(models.GetEvent(ctx, r.client, chainId, hash))
+}
+
+func (r *ClickHouseReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	return models.GetEventsByHashes(ctx, r.client, chainId, hashes)
+}
+
+func (r *ClickHouseReader) GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error) {
+	return notFound(models.GetError(ctx, r.client, signature))
 }
 
 func (r *ClickHouseReader) String() string {
 	return "clickhouse"
 }
+
+// notFound replaces the missing row error with ErrRecordNotFound.
+func notFound[T any](record T, err error) (T, error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return record, ErrRecordNotFound
+	}
+	return record, err
+}
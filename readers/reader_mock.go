@@ -1,6 +1,7 @@
 package readers
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -10,17 +11,42 @@ import (
 // MockReader implements the Reader interface for testing purposes.
 type MockReader struct{}
 
-func (r *MockReader) GetContractByAddress(chainId *big.Int, address common.Address) (*types.Contract, error) {
+func (r *MockReader) GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error) {
 	// Mock implementation
 	return nil, nil
 }
 
-func (r *MockReader) GetMethodBySignature(chainId *big.Int, signature string) (*types.Method, error) {
+func (r *MockReader) GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+	// Mock implementation
+	return map[common.Address]*types.Contract{}, nil
+}
+
+func (r *MockReader) GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error) {
 	// Mock implementation
 	return nil, nil
 }
 
-func (r *MockReader) GetEventByHash(chainId *big.Int, hash common.Hash) (*types.Event, error) {
+func (r *MockReader) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	// Mock implementation
+	return map[string]*types.Method{}, nil
+}
+
+func (r *MockReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	// Mock implementation
+	return nil, nil
+}
+
+func (r *MockReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
+	// Mock implementation
+	return nil, nil
+}
+
+func (r *MockReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	// Mock implementation
+	return map[common.Hash]*types.Event{}, nil
+}
+
+func (r *MockReader) GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error) {
 	// Mock implementation
 	return nil, nil
 }
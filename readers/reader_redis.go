@@ -2,9 +2,11 @@ package readers
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
 	"github.com/txpull/unpack/clients"
 	"github.com/txpull/unpack/types"
 )
@@ -21,9 +23,9 @@ func NewRedisReader(ctx context.Context, client *clients.Redis) (Reader, error)
 	}, nil
 }
 
-func (r *RedisReader) GetContractByAddress(chainId *big.Int, address common.Address) (*types.Contract, error) {
+func (r *RedisReader) GetContractByAddress(ctx context.Context, chainId *big.Int, address common.Address) (*types.Contract, error) {
 	redisKey := types.GetContractStorageKey(chainId, address)
-	contractBytes, err := r.client.Get(r.ctx, redisKey)
+	contractBytes, err := r.get(ctx, redisKey)
 	if err != nil {
 		return nil, err
 	}
@@ -36,9 +38,37 @@ func (r *RedisReader) GetContractByAddress(chainId *big.Int, address common.Addr
 	return contract, nil
 }
 
-func (r *RedisReader) GetMethodBySignature(chainId *big.Int, signature string) (*types.Method, error) {
+// GetContractsByAddresses fetches all of the contracts with a single MGET.
+func (r *RedisReader) GetContractsByAddresses(ctx context.Context, chainId *big.Int, addresses []common.Address) (map[common.Address]*types.Contract, error) {
+	keys := make([]string, len(addresses))
+	for i, address := range addresses {
+		keys[i] = types.GetContractStorageKey(chainId, address)
+	}
+
+	values, err := r.client.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make(map[common.Address]*types.Contract, len(addresses))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		contract := &types.Contract{}
+		if err := contract.UnmarshalBytes(value); err != nil {
+			return nil, err
+		}
+		toReturn[addresses[i]] = contract
+	}
+
+	return toReturn, nil
+}
+
+func (r *RedisReader) GetMethodBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Method, error) {
 	redisKey := types.GetMethodStorageKey(chainId, common.Hex2Bytes(signature))
-	methodBytes, err := r.client.Get(r.ctx, redisKey)
+	methodBytes, err := r.get(ctx, redisKey)
 	if err != nil {
 		return nil, err
 	}
@@ -51,9 +81,57 @@ func (r *RedisReader) GetMethodBySignature(chainId *big.Int, signature string) (
 	return method, nil
 }
 
-func (r *RedisReader) GetEventByHash(chainId *big.Int, hash common.Hash) (*types.Event, error) {
+// GetMethodsBySignatures fetches all of the methods with a single MGET.
+func (r *RedisReader) GetMethodsBySignatures(ctx context.Context, chainId *big.Int, signatures []string) (map[string]*types.Method, error) {
+	keys := make([]string, len(signatures))
+	for i, signature := range signatures {
+		keys[i] = types.GetMethodStorageKey(chainId, common.Hex2Bytes(signature))
+	}
+
+	values, err := r.client.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make(map[string]*types.Method, len(signatures))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		method := &types.Method{}
+		if err := method.UnmarshalBytes(value); err != nil {
+			return nil, err
+		}
+		toReturn[signatures[i]] = method
+	}
+
+	return toReturn, nil
+}
+
+func (r *RedisReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	redisKey := types.GetMethodCandidatesStorageKey(chainId, common.FromHex(selector))
+	candidatesBytes, err := r.get(ctx, redisKey)
+	if err != nil {
+		// Selectors written before candidates were tracked only have the single method stored.
+		method, methodErr := r.GetMethodBySignature(ctx, chainId, selector)
+		if methodErr != nil {
+			return nil, err
+		}
+		return types.Methods{method}, nil
+	}
+
+	var candidates types.Methods
+	if err := candidates.UnmarshalBytes(candidatesBytes); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (r *RedisReader) GetEventByHash(ctx context.Context, chainId *big.Int, hash common.Hash) (*types.Event, error) {
 	redisKey := types.GetEventStorageKey(chainId, hash)
-	eventBytes, err := r.client.Get(r.ctx, redisKey)
+	eventBytes, err := r.get(ctx, redisKey)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +144,98 @@ func (r *RedisReader) GetEventByHash(chainId *big.Int, hash common.Hash) (*types
 	return event, nil
 }
 
+// GetEventsByHashes fetches all of the events with a single MGET.
+func (r *RedisReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	keys := make([]string, len(hashes))
+	for i, hash := range hashes {
+		keys[i] = types.GetEventStorageKey(chainId, hash)
+	}
+
+	values, err := r.client.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make(map[common.Hash]*types.Event, len(hashes))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		event := &types.Event{}
+		if err := event.UnmarshalBytes(value); err != nil {
+			return nil, err
+		}
+		toReturn[hashes[i]] = event
+	}
+
+	return toReturn, nil
+}
+
+func (r *RedisReader) GetErrorBySignature(ctx context.Context, chainId *big.Int, signature string) (*types.Error, error) {
+	redisKey := types.GetErrorStorageKey(chainId, common.FromHex(signature))
+	errorBytes, err := r.get(ctx, redisKey)
+	if err != nil {
+		return nil, err
+	}
+
+	customError := &types.Error{}
+	if err := customError.UnmarshalBytes(errorBytes); err != nil {
+		return nil, err
+	}
+
+	return customError, nil
+}
+
+// WriteContract stores the contract so that the following lookups are served from Redis.
+func (r *RedisReader) WriteContract(ctx context.Context, chainId *big.Int, contract *types.Contract) error {
+	contractBytes, err := contract.MarshalBytes()
+	if err != nil {
+		return err
+	}
+
+	return r.client.Write(ctx, types.GetContractStorageKey(chainId, contract.Address), contractBytes, 0)
+}
+
+// WriteMethod stores the method so that the following lookups are served from Redis.
+func (r *RedisReader) WriteMethod(ctx context.Context, chainId *big.Int, method *types.Method) error {
+	methodBytes, err := method.MarshalBytes()
+	if err != nil {
+		return err
+	}
+
+	return r.client.Write(ctx, types.GetMethodStorageKey(chainId, method.Bytes), methodBytes, 0)
+}
+
+// WriteEvent stores the event so that the following lookups are served from Redis.
+func (r *RedisReader) WriteEvent(ctx context.Context, chainId *big.Int, event *types.Event) error {
+	eventBytes, err := event.MarshalBytes()
+	if err != nil {
+		return err
+	}
+
+	return r.client.Write(ctx, types.GetEventStorageKey(chainId, event.Hash), eventBytes, 0)
+}
+
+// WriteError stores the custom error so that the following lookups are served from Redis.
+func (r *RedisReader) WriteError(ctx context.Context, chainId *big.Int, customError *types.Error) error {
+	errorBytes, err := customError.MarshalBytes()
+	if err != nil {
+		return err
+	}
+
+	return r.client.Write(ctx, types.GetErrorStorageKey(chainId, customError.Bytes), errorBytes, 0)
+}
+
 func (r *RedisReader) String() string {
 	return "redis"
 }
+
+// get retrieves the value of the key, replacing the missing key error with ErrRecordNotFound.
+func (r *RedisReader) get(ctx context.Context, key string) ([]byte, error) {
+	value, err := r.client.Get(ctx, key)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrRecordNotFound
+	}
+	return value, err
+}
@@ -0,0 +1,364 @@
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/txpull/unpack/types"
+	"go.uber.org/zap"
+)
+
+// Default BscScan API URL
+const BSCSCAN_API_URL = "https://api.bscscan.com/api"
+
+const (
+	defaultEtherscanRateLimit  = 5                // Default number of requests per second allowed by the free tier of Etherscan-family explorers
+	defaultEtherscanTimeout    = 30 * time.Second // Default timeout of the single request
+	defaultEtherscanMaxRetries = 5                // Default maximum number of retries of rate limited or failed requests
+	defaultEtherscanBackoff    = time.Second      // Default backoff before the first retry, doubled with every next retry
+)
+
+var (
+	// ErrExplorerNotConfigured is returned when there is no explorer configured for the requested chain.
+	ErrExplorerNotConfigured = errors.New("etherscan explorer is not configured for the chain")
+
+	// ErrRateLimited is returned when the explorer keeps rejecting requests due to the rate limit after all retries.
+	ErrRateLimited = errors.New("explorer rate limit reached")
+
+	// ErrNotVerified is returned when the contract source code is not verified on the explorer.
+	ErrNotVerified = errors.New("contract source code not verified")
+
+	// ErrInvalidAddress is returned when the explorer rejects the contract address.
+	ErrInvalidAddress = errors.New("invalid contract address")
+
+	// ErrExplorerRequestFailed is returned when the explorer responds with any other error.
+	ErrExplorerRequestFailed = errors.New("explorer request failed")
+)
+
+// EtherscanExplorer describes a single Etherscan-compatible block explorer API.
+type EtherscanExplorer struct {
+	Name             string                         // Name of the explorer, e.g. etherscan or bscscan
+	ChainID          int64                          // Chain the explorer is indexing
+	URL              string                         // API URL, e.g. https://api.etherscan.io/api
+	APIKey           string                         // API key, requests are heavily limited without it
+	RateLimit        int                            // Maximum number of requests per second
+	VerificationType types.ContractVerificationType // Verification type of the contracts coming from the explorer
+}
+
+// EtherscanExplorers contains Etherscan-compatible explorers known by default, keyed by the chain ID.
+var EtherscanExplorers = map[int64]EtherscanExplorer{
+	1: {
+		Name:             "etherscan",
+		ChainID:          1,
+		URL:              "https://api.etherscan.io/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+	10: {
+		Name:             "optimistic-etherscan",
+		ChainID:          10,
+		URL:              "https://api-optimistic.etherscan.io/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+	56: {
+		Name:             "bscscan",
+		ChainID:          56,
+		URL:              BSCSCAN_API_URL,
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeBscscan,
+	},
+	137: {
+		Name:             "polygonscan",
+		ChainID:          137,
+		URL:              "https://api.polygonscan.com/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+	250: {
+		Name:             "ftmscan",
+		ChainID:          250,
+		URL:              "https://api.ftmscan.com/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+	8453: {
+		Name:             "basescan",
+		ChainID:          8453,
+		URL:              "https://api.basescan.org/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+	42161: {
+		Name:             "arbiscan",
+		ChainID:          42161,
+		URL:              "https://api.arbiscan.io/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+	43114: {
+		Name:             "snowtrace",
+		ChainID:          43114,
+		URL:              "https://api.snowtrace.io/api",
+		RateLimit:        defaultEtherscanRateLimit,
+		VerificationType: types.ContractVerificationTypeEtherscan,
+	},
+}
+
+type EtherscanContract struct {
+	SourceCode           string `json:"SourceCode"`
+	ABI                  string `json:"ABI"`
+	Name                 string `json:"ContractName"`
+	CompilerVersion      string `json:"CompilerVersion"`
+	OptimizationUsed     string `json:"OptimizationUsed"`
+	Runs                 string `json:"Runs"`
+	ConstructorArguments string `json:"ConstructorArguments"`
+	EVMVersion           string `json:"EVMVersion"`
+	Library              string `json:"Library"`
+	LicenseType          string `json:"LicenseType"`
+	Proxy                string `json:"Proxy"`
+	Implementation       string `json:"Implementation"`
+	SwarmSource          string `json:"SwarmSource"`
+}
+
+// EtherscanResponse represents the explorer response. Result is either the error string or the list
+// of contracts, depending on the status.
+type EtherscanResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// EtherscanProvider represents the scanner provider of any Etherscan-compatible explorer
+// (Etherscan, BscScan, Polygonscan, Arbiscan...). Requests are limited by the token bucket sized
+// for the API key tier, rate limited and failed requests are retried with exponential backoff.
+type EtherscanProvider struct {
+	explorer   EtherscanExplorer
+	client     *http.Client
+	limiter    *tokenBucket
+	maxRetries int
+	backoff    time.Duration
+}
+
+// EtherscanOption is a function that applies a configuration option to an EtherscanProvider.
+type EtherscanOption func(*EtherscanProvider)
+
+// WithEtherscanHTTPClient is an EtherscanOption to set the HTTP client used to query the explorer.
+func WithEtherscanHTTPClient(client *http.Client) EtherscanOption {
+	return func(p *EtherscanProvider) {
+		p.client = client
+	}
+}
+
+// WithEtherscanMaxRetries is an EtherscanOption to set the maximum number of retries of rate limited or failed requests.
+func WithEtherscanMaxRetries(maxRetries int) EtherscanOption {
+	return func(p *EtherscanProvider) {
+		p.maxRetries = maxRetries
+	}
+}
+
+// WithEtherscanBackoff is an EtherscanOption to set the backoff before the first retry, it is doubled with every next retry.
+func WithEtherscanBackoff(backoff time.Duration) EtherscanOption {
+	return func(p *EtherscanProvider) {
+		p.backoff = backoff
+	}
+}
+
+// NewEtherscanProvider creates a new instance of EtherscanProvider for the provided explorer.
+func NewEtherscanProvider(explorer EtherscanExplorer, opts ...EtherscanOption) *EtherscanProvider {
+	if explorer.RateLimit <= 0 {
+		explorer.RateLimit = defaultEtherscanRateLimit
+	}
+
+	provider := &EtherscanProvider{
+		explorer:   explorer,
+		client:     &http.Client{Timeout: defaultEtherscanTimeout},
+		limiter:    newTokenBucket(explorer.RateLimit, explorer.RateLimit),
+		maxRetries: defaultEtherscanMaxRetries,
+		backoff:    defaultEtherscanBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(provider)
+	}
+
+	return provider
+}
+
+// Explorer returns the explorer the provider is querying.
+func (p *EtherscanProvider) Explorer() EtherscanExplorer {
+	return p.explorer
+}
+
+// ScanContract scans the contract using the explorer API. Rate limited and failed requests are retried,
+// errors callers may want to branch on are ErrRateLimited, ErrNotVerified and ErrInvalidAddress.
+func (p *EtherscanProvider) ScanContract(ctx context.Context, contractAddress string) (*EtherscanContract, error) {
+	var lastErr error
+
+	for i := 0; i <= p.maxRetries; i++ {
+		if i > 0 {
+			backoff := time.Duration(float64(p.backoff) * math.Pow(2, float64(i-1)))
+
+			zap.L().Debug(
+				"Retrying explorer request",
+				zap.String("explorer", p.explorer.Name),
+				zap.String("contract_address", contractAddress),
+				zap.Int("retry_attempt", i),
+				zap.Duration("backoff", backoff),
+				zap.Error(lastErr),
+			)
+
+			if err := sleepCtx(ctx, backoff); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := p.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		contract, retry, err := p.scanContract(ctx, contractAddress)
+		if err == nil {
+			return contract, nil
+		}
+
+		if !retry || ctx.Err() != nil {
+			return nil, err
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// scanContract sends the single request to the explorer and reports whether the failed request should be retried.
+func (p *EtherscanProvider) scanContract(ctx context.Context, contractAddress string) (*EtherscanContract, bool, error) {
+	query := url.Values{}
+	query.Set("module", "contract")
+	query.Set("action", "getsourcecode")
+	query.Set("address", contractAddress)
+	query.Set("apikey", p.explorer.APIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.explorer.URL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, true, fmt.Errorf("%w: %s", ErrRateLimited, resp.Status)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, true, fmt.Errorf("%w: %s", ErrExplorerRequestFailed, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var envelope EtherscanResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal %s response: %w", p.explorer.Name, err)
+	}
+
+	if envelope.Status != "1" {
+		var result string
+		if err := json.Unmarshal(envelope.Result, &result); err != nil {
+			result = envelope.Message
+		}
+
+		return nil, isRateLimitResult(result), classifyExplorerError(result)
+	}
+
+	var contracts []EtherscanContract
+	if err := json.Unmarshal(envelope.Result, &contracts); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal %s response: %w", p.explorer.Name, err)
+	}
+
+	if len(contracts) == 0 || contracts[0].ABI == "Contract source code not verified" {
+		return nil, false, ErrNotVerified
+	}
+
+	return &contracts[0], false, nil
+}
+
+// classifyExplorerError converts the error result of the explorer into the typed error.
+func classifyExplorerError(result string) error {
+	lower := strings.ToLower(result)
+
+	switch {
+	case isRateLimitResult(result):
+		return fmt.Errorf("%w: %s", ErrRateLimited, result)
+	case strings.Contains(lower, "invalid address"):
+		return fmt.Errorf("%w: %s", ErrInvalidAddress, result)
+	case strings.Contains(lower, "not verified"):
+		return fmt.Errorf("%w: %s", ErrNotVerified, result)
+	default:
+		return fmt.Errorf("%w: %s", ErrExplorerRequestFailed, result)
+	}
+}
+
+// isRateLimitResult reports whether the error result is the rate limit one, e.g. "Max rate limit reached"
+// or "Max calls per sec rate limit reached (5/sec)".
+func isRateLimitResult(result string) bool {
+	return strings.Contains(strings.ToLower(result), "rate limit")
+}
+
+// EtherscanProviders holds Etherscan-compatible providers keyed by the chain ID.
+type EtherscanProviders struct {
+	providers map[int64]*EtherscanProvider
+}
+
+// NewEtherscanProviders creates providers for each of the explorers, keyed by the explorer chain ID.
+func NewEtherscanProviders(explorers []EtherscanExplorer, opts ...EtherscanOption) *EtherscanProviders {
+	toReturn := &EtherscanProviders{
+		providers: make(map[int64]*EtherscanProvider, len(explorers)),
+	}
+
+	for _, explorer := range explorers {
+		toReturn.providers[explorer.ChainID] = NewEtherscanProvider(explorer, opts...)
+	}
+
+	return toReturn
+}
+
+// Get returns the provider of the explorer indexing the chain.
+func (p *EtherscanProviders) Get(chainId *big.Int) (*EtherscanProvider, error) {
+	if p == nil || chainId == nil {
+		return nil, ErrExplorerNotConfigured
+	}
+
+	provider, ok := p.providers[chainId.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrExplorerNotConfigured, chainId)
+	}
+
+	return provider, nil
+}
+
+// ScanContract scans the contract using the explorer indexing the chain.
+func (p *EtherscanProviders) ScanContract(ctx context.Context, chainId *big.Int, contractAddress string) (*EtherscanContract, error) {
+	provider, err := p.Get(chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.ScanContract(ctx, contractAddress)
+}
@@ -0,0 +1,169 @@
+package scanners
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/txpull/unpack/types"
+)
+
+// ErrSourceCodeNotAvailable is returned when the explorer did not return any source code for the contract.
+var ErrSourceCodeNotAvailable = errors.New("source code is not available")
+
+// standardJSONInput represents the solidity standard JSON input submitted to the explorer.
+type standardJSONInput struct {
+	Language string                 `json:"language"`
+	Sources  map[string]sourceEntry `json:"sources"`
+	Settings json.RawMessage        `json:"settings"`
+}
+
+type sourceEntry struct {
+	Content string `json:"content"`
+}
+
+// compilerSettings represents the subset of compiler settings we are interested in.
+type compilerSettings struct {
+	Optimizer struct {
+		Enabled bool `json:"enabled"`
+		Runs    int  `json:"runs"`
+	} `json:"optimizer"`
+	EVMVersion string   `json:"evmVersion,omitempty"`
+	Remappings []string `json:"remappings,omitempty"`
+}
+
+// ParseSourceCode detects the format the source code was submitted in and parses it into the source tree.
+// Explorers return either the single (flattened) file, the JSON object of multiple files or the standard
+// JSON input wrapped in double braces, e.g. {{"language": "Solidity", "sources": {...}, "settings": {...}}}.
+func (c *EtherscanContract) ParseSourceCode(chainId *big.Int, address common.Address) (*types.ContractSource, error) {
+	sourceCode := strings.TrimSpace(c.SourceCode)
+	if len(sourceCode) == 0 {
+		return nil, ErrSourceCodeNotAvailable
+	}
+
+	toReturn := &types.ContractSource{
+		UUID:     uuid.New(),
+		ChainID:  chainId,
+		Address:  address,
+		Language: c.language(),
+		Sources:  make(map[string]string),
+	}
+
+	if err := c.parseSources(sourceCode, toReturn); err != nil {
+		return nil, err
+	}
+
+	// Single and multi-file formats do not carry compiler settings, explorer fields describe them instead.
+	if len(toReturn.Settings) == 0 {
+		settings, err := c.explorerSettings()
+		if err != nil {
+			return nil, err
+		}
+		toReturn.Settings = settings
+	}
+
+	return toReturn, nil
+}
+
+// parseSources fills the source tree, format and (standard JSON only) compiler settings of the source.
+func (c *EtherscanContract) parseSources(sourceCode string, source *types.ContractSource) error {
+	if !strings.HasPrefix(sourceCode, "{") {
+		source.Format = types.SourceFormatSingleFile
+		source.Sources[c.singleFilePath()] = c.SourceCode
+		return nil
+	}
+
+	if strings.HasPrefix(sourceCode, "{{") && strings.HasSuffix(sourceCode, "}}") {
+		sourceCode = sourceCode[1 : len(sourceCode)-1]
+	}
+
+	var input standardJSONInput
+	if err := json.Unmarshal([]byte(sourceCode), &input); err == nil && len(input.Sources) > 0 {
+		source.Format = types.SourceFormatStandardJSON
+		if len(input.Language) > 0 {
+			source.Language = types.ToContractLanguage(input.Language)
+		}
+
+		for path, entry := range input.Sources {
+			source.Sources[path] = entry.Content
+		}
+
+		if len(input.Settings) == 0 {
+			return nil
+		}
+
+		var settings compilerSettings
+		if err := json.Unmarshal(input.Settings, &settings); err != nil {
+			return fmt.Errorf("failed to unmarshal compiler settings: %w", err)
+		}
+
+		source.Settings = string(input.Settings)
+		source.Remappings = settings.Remappings
+
+		return nil
+	}
+
+	var sources map[string]sourceEntry
+	if err := json.Unmarshal([]byte(sourceCode), &sources); err != nil {
+		return fmt.Errorf("failed to unmarshal multi-file source code: %w", err)
+	}
+
+	if len(sources) == 0 {
+		return ErrSourceCodeNotAvailable
+	}
+
+	source.Format = types.SourceFormatMultiFile
+	for path, entry := range sources {
+		source.Sources[path] = entry.Content
+	}
+
+	return nil
+}
+
+// explorerSettings builds compiler settings out of the explorer fields.
+func (c *EtherscanContract) explorerSettings() (string, error) {
+	var settings compilerSettings
+
+	settings.Optimizer.Enabled = c.OptimizationUsed == "1"
+	if runs, err := strconv.Atoi(c.Runs); err == nil {
+		settings.Optimizer.Runs = runs
+	}
+
+	// Explorers report "Default" when the compiler default EVM version was used.
+	if !strings.EqualFold(c.EVMVersion, "default") {
+		settings.EVMVersion = strings.ToLower(c.EVMVersion)
+	}
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal compiler settings: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+func (c *EtherscanContract) language() types.ContractLanguage {
+	if strings.Contains(strings.ToLower(c.CompilerVersion), "vyper") {
+		return types.ContractLanguageVyper
+	}
+
+	return types.ContractLanguageSolidity
+}
+
+func (c *EtherscanContract) singleFilePath() string {
+	name := c.Name
+	if len(name) == 0 {
+		name = "Contract"
+	}
+
+	if c.language() == types.ContractLanguageVyper {
+		return name + ".vy"
+	}
+
+	return name + ".sol"
+}
@@ -0,0 +1,126 @@
+package scanners
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/types"
+)
+
+func TestEtherscanContract_ParseSourceCode(t *testing.T) {
+	chainId := big.NewInt(56)
+	address := common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE")
+
+	testCases := []struct {
+		name               string
+		contract           EtherscanContract
+		expectedFormat     types.SourceFormat
+		expectedLanguage   types.ContractLanguage
+		expectedPaths      []string
+		expectedSettings   string
+		expectedRemappings []string
+	}{
+		{
+			name: "single file",
+			contract: EtherscanContract{
+				Name:             "Token",
+				SourceCode:       "pragma solidity ^0.8.0;\ncontract Token {}",
+				CompilerVersion:  "v0.8.9+commit.e5eed63a",
+				OptimizationUsed: "1",
+				Runs:             "200",
+				EVMVersion:       "Default",
+			},
+			expectedFormat:   types.SourceFormatSingleFile,
+			expectedLanguage: types.ContractLanguageSolidity,
+			expectedPaths:    []string{"Token.sol"},
+			expectedSettings: `{"optimizer":{"enabled":true,"runs":200}}`,
+		},
+		{
+			name: "vyper single file",
+			contract: EtherscanContract{
+				Name:            "Vault",
+				SourceCode:      "# @version 0.3.7\n",
+				CompilerVersion: "vyper:0.3.7",
+				EVMVersion:      "London",
+			},
+			expectedFormat:   types.SourceFormatSingleFile,
+			expectedLanguage: types.ContractLanguageVyper,
+			expectedPaths:    []string{"Vault.vy"},
+			expectedSettings: `{"optimizer":{"enabled":false,"runs":0},"evmVersion":"london"}`,
+		},
+		{
+			name: "multi file",
+			contract: EtherscanContract{
+				Name:       "Token",
+				SourceCode: `{"contracts/Token.sol": {"content": "import \"./IToken.sol\";"}, "contracts/IToken.sol": {"content": "interface IToken {}"}}`,
+				Runs:       "200",
+			},
+			expectedFormat:   types.SourceFormatMultiFile,
+			expectedLanguage: types.ContractLanguageSolidity,
+			expectedPaths:    []string{"contracts/IToken.sol", "contracts/Token.sol"},
+			expectedSettings: `{"optimizer":{"enabled":false,"runs":200}}`,
+		},
+		{
+			name: "standard json",
+			contract: EtherscanContract{
+				Name: "Token",
+				SourceCode: `{{
+					"language": "Solidity",
+					"sources": {"src/Token.sol": {"content": "contract Token {}"}, "lib/oz/ERC20.sol": {"content": "contract ERC20 {}"}},
+					"settings": {"optimizer": {"enabled": true, "runs": 10000}, "remappings": ["@oz/=lib/oz/"]}
+				}}`,
+			},
+			expectedFormat:     types.SourceFormatStandardJSON,
+			expectedLanguage:   types.ContractLanguageSolidity,
+			expectedPaths:      []string{"lib/oz/ERC20.sol", "src/Token.sol"},
+			expectedSettings:   `{"optimizer": {"enabled": true, "runs": 10000}, "remappings": ["@oz/=lib/oz/"]}`,
+			expectedRemappings: []string{"@oz/=lib/oz/"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source, err := tc.contract.ParseSourceCode(chainId, address)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if source.Format != tc.expectedFormat {
+				t.Errorf("unexpected format; got %s, want %s", source.Format, tc.expectedFormat)
+			}
+
+			if source.Language != tc.expectedLanguage {
+				t.Errorf("unexpected language; got %s, want %s", source.Language, tc.expectedLanguage)
+			}
+
+			paths := source.Paths()
+			if len(paths) != len(tc.expectedPaths) {
+				t.Fatalf("unexpected paths; got %v, want %v", paths, tc.expectedPaths)
+			}
+
+			for i := range paths {
+				if paths[i] != tc.expectedPaths[i] {
+					t.Errorf("unexpected path; got %s, want %s", paths[i], tc.expectedPaths[i])
+				}
+			}
+
+			if source.Settings != tc.expectedSettings {
+				t.Errorf("unexpected settings; got %s, want %s", source.Settings, tc.expectedSettings)
+			}
+
+			if len(source.Remappings) != len(tc.expectedRemappings) {
+				t.Errorf("unexpected remappings; got %v, want %v", source.Remappings, tc.expectedRemappings)
+			}
+
+			if source.ChainID.Cmp(chainId) != 0 || source.Address != address {
+				t.Errorf("unexpected contract; got %s/%s", source.ChainID, source.Address.Hex())
+			}
+		})
+	}
+
+	if _, err := (&EtherscanContract{}).ParseSourceCode(chainId, address); !errors.Is(err, ErrSourceCodeNotAvailable) {
+		t.Errorf("unexpected error; got %v, want %s", err, ErrSourceCodeNotAvailable)
+	}
+}
@@ -0,0 +1,166 @@
+package scanners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEtherscanProvider_ScanContract(t *testing.T) {
+	// Create a mock HTTP server for Etherscan-compatible API
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Respond with a sample BscScan response for a known contract address
+		if r.URL.Query().Get("address") == "0x123456789abcdef" {
+			response := `{"status": "1", "message": "OK", "result": [{"ContractName": "MyContract", "CompilerVersion": "0.8.9"}]}`
+			fmt.Fprintln(w, response)
+			return
+		}
+
+		// Respond with a sample BscScan response for a not known contract address
+		if r.URL.Query().Get("address") == "0x123456789abcdefg" {
+			response := `{"status": "1", "message": "OK", "result": [{"ContractName": "", "ABI": "Contract source code not verified"}]}`
+			fmt.Fprintln(w, response)
+			return
+		}
+
+		// Respond with a non-successful response for invalid contract addresses
+		response := `{"status":"0","message":"NOTOK","result":"Invalid Address format"}`
+		fmt.Fprintln(w, response)
+	}))
+	defer server.Close()
+
+	// Create a BscScan provider with the mock server URL
+	bscScanProvider := NewEtherscanProvider(EtherscanExplorer{Name: "bscscan", ChainID: 56, URL: server.URL, RateLimit: 100}, WithEtherscanBackoff(time.Millisecond))
+
+	// Define test cases for different contract addresses
+	testCases := []struct {
+		contractAddress  string
+		expectedName     string
+		expectedCompiler string
+		expectedError    error
+	}{
+		{
+			contractAddress:  "0x123456789abcdef",
+			expectedName:     "MyContract",
+			expectedCompiler: "0.8.9",
+		},
+		{
+			contractAddress:  "0x123456789abcdefg",
+			expectedName:     "MyContract",
+			expectedCompiler: "0.8.9",
+			expectedError:    ErrNotVerified,
+		},
+		{
+			contractAddress: "0xabcdef123456789",
+			expectedError:   ErrInvalidAddress,
+		},
+	}
+
+	// Run sub-tests for each test case
+	for _, tc := range testCases {
+		t.Run(tc.contractAddress, func(t *testing.T) {
+			// Perform the contract scan
+			result, err := bscScanProvider.ScanContract(context.Background(), tc.contractAddress)
+			if tc.expectedError != nil {
+				// Verify that an error occurred as expected
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("unexpected error; got %v, want %s", err, tc.expectedError)
+				}
+			} else {
+				// Verify the contract information
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+
+				if result.Name != tc.expectedName {
+					t.Errorf("unexpected contract name; got %s, want %s", result.Name, tc.expectedName)
+				}
+				if result.CompilerVersion != tc.expectedCompiler {
+					t.Errorf("unexpected compiler version; got %s, want %s", result.CompilerVersion, tc.expectedCompiler)
+				}
+			}
+		})
+	}
+}
+
+func TestEtherscanProviders_Get(t *testing.T) {
+	providers := NewEtherscanProviders([]EtherscanExplorer{EtherscanExplorers[1], EtherscanExplorers[56]})
+
+	provider, err := providers.Get(big.NewInt(56))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if provider.Explorer().Name != "bscscan" {
+		t.Errorf("unexpected explorer; got %s, want bscscan", provider.Explorer().Name)
+	}
+
+	if _, err := providers.Get(big.NewInt(137)); !errors.Is(err, ErrExplorerNotConfigured) {
+		t.Errorf("unexpected error; got %v, want %s", err, ErrExplorerNotConfigured)
+	}
+}
+
+func TestEtherscanProvider_ScanContractRetriesRateLimit(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			fmt.Fprintln(w, `{"status":"0","message":"NOTOK","result":"Max calls per sec rate limit reached (5/sec)"}`)
+			return
+		}
+
+		fmt.Fprintln(w, `{"status": "1", "message": "OK", "result": [{"ContractName": "MyContract"}]}`)
+	}))
+	defer server.Close()
+
+	explorer := EtherscanExplorer{Name: "etherscan", ChainID: 1, URL: server.URL, RateLimit: 100}
+
+	provider := NewEtherscanProvider(explorer, WithEtherscanBackoff(time.Millisecond))
+
+	result, err := provider.ScanContract(context.Background(), "0x123456789abcdef")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Name != "MyContract" || atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("unexpected result; got %s after %d requests", result.Name, requests)
+	}
+
+	// Provider gives up once retries are exhausted and reports the rate limit to the caller.
+	atomic.StoreInt32(&requests, -10)
+
+	provider = NewEtherscanProvider(explorer, WithEtherscanBackoff(time.Millisecond), WithEtherscanMaxRetries(2))
+
+	if _, err := provider.ScanContract(context.Background(), "0x123456789abcdef"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("unexpected error; got %v, want %s", err, ErrRateLimited)
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := newTokenBucket(20, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// Two tokens are available right away, the other two are refilled at 20 tokens per second.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("bucket did not limit requests; took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := bucket.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error; got %v, want %s", err, context.Canceled)
+	}
+}
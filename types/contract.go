@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/hex"
 	"encoding/json"
 	"math/big"
 	"strconv"
@@ -76,7 +77,8 @@ type Contract struct {
 	Runs                 string                   `json:"runs"`
 	ConstructorArguments string                   `json:"constructor_arguments"`
 	RuntimeBytecode      []byte                   `json:"runtime_bytecode"`
-	Bytecode             []byte                   `json:"bytecode"`
+	Bytecode             []byte                   `json:"bytecode"`       // compiled creation (init) bytecode
+	CreationInput        []byte                   `json:"creation_input"` // creation input, init bytecode followed by constructor arguments
 	EVMVersion           string                   `json:"evm_version"`
 	Library              string                   `json:"library"`
 	LicenseType          string                   `json:"license_type"`
@@ -113,6 +115,17 @@ func (r *Contract) UnmarshalBytes(data []byte) error {
 	return nil
 }
 
+// GetConstructorArguments returns ABI encoded constructor arguments decoded from the stored hex string.
+// It returns nil if constructor arguments are not known.
+func (r *Contract) GetConstructorArguments() ([]byte, error) {
+	arguments := strings.TrimSpace(r.ConstructorArguments)
+	if len(arguments) == 0 {
+		return nil, nil
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(arguments, "0x"))
+}
+
 // Unmarshal the ABI from JSON
 func (r *Contract) UnmarshalABI() (*abi.ABI, error) {
 	parsedAbi, err := abi.JSON(strings.NewReader(r.ABI))
@@ -123,6 +136,16 @@ func (r *Contract) UnmarshalABI() (*abi.ABI, error) {
 	return &parsedAbi, nil
 }
 
+// UnmarshalConstructorABI parses the constructor ABI entry stored separately from the contract ABI.
+func (r *Contract) UnmarshalConstructorABI() (*abi.Method, error) {
+	parsedAbi, err := abi.JSON(strings.NewReader("[" + r.ConstructorABI + "]"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsedAbi.Constructor, nil
+}
+
 func NewContractFromSourcify(chainId *big.Int, address common.Address, metadata *sourcify.Metadata, metadataBytes []byte) (*Contract, error) {
 	contract := &Contract{
 		UUID:             uuid.New(),
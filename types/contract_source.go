@@ -0,0 +1,76 @@
+package types
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// SourceFormat represents the format the contract source code was submitted to the explorer in.
+type SourceFormat string
+
+const (
+	SourceFormatSingleFile   SourceFormat = "single_file"   // Flattened source code stored as is
+	SourceFormatMultiFile    SourceFormat = "multi_file"    // JSON object of {"path": {"content": "..."}}
+	SourceFormatStandardJSON SourceFormat = "standard_json" // Solidity standard JSON input wrapped in double braces
+)
+
+// ContractSource represents the source tree of the verified contract together with the compiler
+// settings it was compiled with.
+type ContractSource struct {
+	UUID uuid.UUID `json:"uuid"`
+
+	ChainID *big.Int `json:"chain_id"`
+
+	// Address represents the address of the contract.
+	Address common.Address `json:"address"`
+
+	Format   SourceFormat     `json:"format"`
+	Language ContractLanguage `json:"language"`
+
+	// Sources maps the source path onto the source content, e.g. contracts/Token.sol => pragma solidity...
+	Sources map[string]string `json:"sources"`
+
+	// Settings represents compiler settings of the standard JSON input, encoded as JSON.
+	Settings string `json:"settings"`
+
+	// Remappings represents import remappings, e.g. @openzeppelin/=lib/openzeppelin-contracts/
+	Remappings []string `json:"remappings"`
+}
+
+// Paths returns sorted paths of the source files.
+func (r *ContractSource) Paths() []string {
+	toReturn := make([]string, 0, len(r.Sources))
+	for path := range r.Sources {
+		toReturn = append(toReturn, path)
+	}
+	sort.Strings(toReturn)
+
+	return toReturn
+}
+
+func (r *ContractSource) MarshalBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := enc.Encode(r); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (r *ContractSource) UnmarshalBytes(data []byte) error {
+	buffer := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buffer)
+	err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
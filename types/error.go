@@ -0,0 +1,70 @@
+package types
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// Error represents a custom solidity error, e.g. `error InsufficientBalance(uint256 available, uint256 required)`.
+// Errors are identified by their 4 byte selector in the same way as methods are.
+type Error struct {
+	UUID      uuid.UUID        `json:"uuid"`
+	Name      string           `json:"name"`
+	Signature string           `json:"signature"`
+	Hex       string           `json:"hex"`
+	Bytes     []byte           `json:"bytes"`
+	IsPartial bool             `json:"is_partial"`
+	Arguments []MethodArgument `json:"arguments"`
+}
+
+func NewFullError(abiError abi.Error) *Error {
+	toReturn := Error{
+		UUID:      uuid.New(),
+		Name:      abiError.Name,
+		Signature: abiError.Sig,
+		Hex:       common.Bytes2Hex(abiError.ID[:4]),
+		Bytes:     abiError.ID[:4],
+		IsPartial: false, // This is a fully processed error so it is not partial
+	}
+
+	for i, arg := range abiError.Inputs {
+		toReturn.Arguments = append(toReturn.Arguments, newArgument(arg.Name, arg.Type, i))
+	}
+
+	return &toReturn
+}
+
+// GetABIArguments converts error arguments into go-ethereum abi.Arguments.
+func (m *Error) GetABIArguments() (abi.Arguments, error) {
+	return toABIArguments(m.Arguments)
+}
+
+func (m *Error) GetArgumentsAsJSON() string {
+	return toJSON(m.Arguments)
+}
+
+func (m *Error) MarshalBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *Error) UnmarshalBytes(data []byte) error {
+	buffer := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buffer)
+	err := dec.Decode(m)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
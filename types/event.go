@@ -3,6 +3,8 @@ package types
 import (
 	"bytes"
 	"encoding/gob"
+	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,9 +23,10 @@ type Event struct {
 }
 
 type EventArgument struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Indexed bool   `json:"indexed"`
+	Name       string           `json:"name"`
+	Type       string           `json:"type"`
+	Indexed    bool             `json:"indexed"`
+	Components []MethodArgument `json:"components,omitempty"` // Tuple components, the type is then tuple, tuple[] or tuple[N]
 }
 
 func NewFullEvent(event abi.Event) *Event {
@@ -37,15 +40,71 @@ func NewFullEvent(event abi.Event) *Event {
 		IsPartial:   false, // This is a fully processed event so it is not partial
 	}
 
-	for _, arg := range event.Inputs {
+	for i, arg := range event.Inputs {
+		argument := newArgument(arg.Name, arg.Type, i)
+
 		toReturn.Arguments = append(toReturn.Arguments, EventArgument{
+			Name:       argument.Name,
+			Type:       argument.Type,
+			Indexed:    arg.Indexed,
+			Components: argument.Components,
+		})
+	}
+
+	return &toReturn
+}
+
+// NewFourByteEvent creates the partial event out of the 4byte.directory event signature entry.
+// Event signatures do not carry information about which arguments are indexed, therefore none of them are.
+func NewFourByteEvent(hexSignature string, signature string) (*Event, error) {
+	hash := strings.TrimPrefix(hexSignature, "0x")
+	if len(hash) != common.HashLength*2 {
+		return nil, fmt.Errorf("invalid event hash length: %d", len(hash))
+	}
+
+	name, arguments, err := ParseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := Event{
+		UUID:      uuid.New(),
+		Name:      name,
+		RawName:   name,
+		Signature: signature,
+		Hash:      common.HexToHash(hash),
+		IsPartial: true,
+	}
+
+	for _, arg := range arguments {
+		toReturn.Arguments = append(toReturn.Arguments, EventArgument{
+			Type:       arg.Type,
+			Components: arg.Components,
+		})
+	}
+
+	return &toReturn, nil
+}
+
+// GetABIArguments converts event arguments into go-ethereum abi.Arguments, preserving
+// the indexed flag so that topics and data can be unpacked separately.
+func (m *Event) GetABIArguments() (abi.Arguments, error) {
+	toReturn := make(abi.Arguments, 0, len(m.Arguments))
+
+	for _, arg := range m.Arguments {
+		argType, err := newABIType(MethodArgument{Name: arg.Name, Type: arg.Type, Components: arg.Components})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse argument %q type %q: %w", arg.Name, arg.Type, err)
+		}
+
+		toReturn = append(toReturn, abi.Argument{
 			Name:    arg.Name,
-			Type:    arg.Type.String(),
+			Type:    argType,
 			Indexed: arg.Indexed,
 		})
 	}
 
-	return &toReturn
+	return toReturn, nil
 }
 
 func (m *Event) GetArgumentsAsJSON() string {
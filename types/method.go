@@ -5,7 +5,6 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -24,6 +23,7 @@ type Method struct {
 	IsConstant      bool             `json:"is_constant"`
 	IsPayable       bool             `json:"is_payable"`
 	IsPartial       bool             `json:"is_partial"`
+	FourByteID      int64            `json:"fourbyte_id"` // 4byte directory id of partial methods, lower is older
 	Type            abi.FunctionType `json:"type"`
 	StateMutability string           `json:"state_mutability"`
 	Arguments       []MethodArgument `json:"arguments"`
@@ -31,9 +31,10 @@ type Method struct {
 }
 
 type MethodArgument struct {
-	Name  string `json:"name"`
-	Type  string `json:"type"`
-	Index int    `json:"index"` // Used for partial data to help matching the output in the future
+	Name       string           `json:"name"`
+	Type       string           `json:"type"`
+	Index      int              `json:"index"`                // Used for partial data to help matching the output in the future
+	Components []MethodArgument `json:"components,omitempty"` // Tuple components, the type is then tuple, tuple[] or tuple[N]
 }
 
 func NewFullMethod(method abi.Method) *Method {
@@ -50,30 +51,31 @@ func NewFullMethod(method abi.Method) *Method {
 		StateMutability: method.StateMutability,
 		IsPartial:       false, // This is a fully processed method so it is not partial
 	}
-	for _, arg := range method.Inputs {
-		toReturn.Arguments = append(toReturn.Arguments, MethodArgument{
-			Name: arg.Name,
-			Type: arg.Type.String(),
-		})
+	for i, arg := range method.Inputs {
+		toReturn.Arguments = append(toReturn.Arguments, newArgument(arg.Name, arg.Type, i))
 	}
 
-	for _, arg := range method.Outputs {
-		toReturn.Returns = append(toReturn.Returns, MethodArgument{
-			Name: arg.Name,
-			Type: arg.Type.String(),
-		})
+	for i, arg := range method.Outputs {
+		toReturn.Returns = append(toReturn.Returns, newArgument(arg.Name, arg.Type, i))
 	}
 
 	return &toReturn
 }
 
-func NewFourByteMethod(hexSignature string, name string, signature string, arguments []string) (*Method, error) {
-	method := strings.TrimLeft(hexSignature, "0x") // We don't want the 0x prefix
+// NewFourByteMethod creates the partial method out of the 4byte.directory function signature entry.
+// Arguments, including tuples and nested arrays, are parsed out of the signature itself.
+func NewFourByteMethod(hexSignature string, signature string) (*Method, error) {
+	method := strings.TrimPrefix(hexSignature, "0x") // We don't want the 0x prefix
 
 	if len(method)%2 != 0 {
 		return nil, fmt.Errorf("invalid method length: %d", len(method))
 	}
 
+	name, arguments, err := ParseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
 	toReturn := Method{
 		UUID:      uuid.New(),
 		Name:      name,
@@ -81,6 +83,7 @@ func NewFourByteMethod(hexSignature string, name string, signature string, argum
 		Signature: signature,
 		Hex:       method,
 		IsPartial: true,
+		Arguments: arguments,
 	}
 
 	var signatureBytes []byte
@@ -95,21 +98,18 @@ func NewFourByteMethod(hexSignature string, name string, signature string, argum
 	}
 	toReturn.Bytes = signatureBytes
 
-	re := regexp.MustCompile(`[\d\[\]]+`)
-
-	for i := 0; i < len(arguments); i++ {
-		if i+1 < len(arguments) {
-			name := re.ReplaceAllString(arguments[i], "")
+	return &toReturn, nil
+}
 
-			toReturn.Arguments = append(toReturn.Arguments, MethodArgument{
-				Name:  name,
-				Type:  arguments[i],
-				Index: i,
-			})
-		}
-	}
+// GetABIArguments converts method arguments into go-ethereum abi.Arguments so that
+// calldata can be unpacked against the stored (full or partial) method definition.
+func (m *Method) GetABIArguments() (abi.Arguments, error) {
+	return toABIArguments(m.Arguments)
+}
 
-	return &toReturn, nil
+// GetABIReturns converts method return values into go-ethereum abi.Arguments.
+func (m *Method) GetABIReturns() (abi.Arguments, error) {
+	return toABIArguments(m.Returns)
 }
 
 func (m *Method) GetArgumentsAsJSON() string {
@@ -142,6 +142,60 @@ func (m *Method) UnmarshalBytes(data []byte) error {
 	return nil
 }
 
+// Methods represents all of the method candidates sharing the same selector.
+type Methods []*Method
+
+// Contains reports whether the method with provided signature is already among the candidates.
+func (m Methods) Contains(signature string) bool {
+	for _, method := range m {
+		if method.Signature == signature {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m Methods) MarshalBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *Methods) UnmarshalBytes(data []byte) error {
+	buffer := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buffer)
+	err := dec.Decode(m)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func toABIArguments(arguments []MethodArgument) (abi.Arguments, error) {
+	toReturn := make(abi.Arguments, 0, len(arguments))
+
+	for _, arg := range arguments {
+		argType, err := newABIType(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse argument %q type %q: %w", arg.Name, arg.Type, err)
+		}
+
+		toReturn = append(toReturn, abi.Argument{
+			Name: arg.Name,
+			Type: argType,
+		})
+	}
+
+	return toReturn, nil
+}
+
 func toJSON(data interface{}) string {
 	bytes, err := json.Marshal(data)
 	if err != nil {
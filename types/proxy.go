@@ -0,0 +1,42 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// ProxyType represents the standard (or well known pattern) the proxy contract implements.
+type ProxyType string
+
+const (
+	ProxyTypeUnknown       ProxyType = "unknown"
+	ProxyTypeEIP1167       ProxyType = "eip1167"
+	ProxyTypeEIP1967       ProxyType = "eip1967"
+	ProxyTypeEIP1967Beacon ProxyType = "eip1967_beacon"
+	ProxyTypeTransparent   ProxyType = "transparent"
+	ProxyTypeEIP1822       ProxyType = "eip1822"
+	ProxyTypeOpenZeppelin  ProxyType = "openzeppelin"
+	ProxyTypeEIP897        ProxyType = "eip897"
+	ProxyTypeGnosisSafe    ProxyType = "gnosis_safe"
+)
+
+// Proxy represents the link between the proxy contract and the implementation contract it delegates calls to.
+type Proxy struct {
+	UUID uuid.UUID `json:"uuid"`
+
+	ChainID *big.Int `json:"chain_id"`
+
+	// Address represents the address of the proxy contract.
+	Address common.Address `json:"address"`
+
+	// Implementation represents the address of the contract the proxy delegates calls to.
+	Implementation common.Address `json:"implementation"`
+
+	// Beacon represents the address of the beacon contract for the beacon proxies.
+	Beacon *common.Address `json:"beacon,omitempty"`
+
+	// Type represents the detected proxy standard.
+	Type ProxyType `json:"type"`
+}
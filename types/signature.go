@@ -0,0 +1,230 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// arraySuffixRegex matches the (possibly nested) array suffix of the type, e.g. [], [2][] or [3].
+var arraySuffixRegex = regexp.MustCompile(`^(\[[0-9]*\])*$`)
+
+// ParseSignature parses the canonical Solidity signature, e.g. swap((address,uint256)[],bytes),
+// into the name and the arguments. Tuples are described the same way as in the JSON ABI, that is
+// with the tuple type (tuple, tuple[], tuple[2]...) and the components. Signatures carry no argument
+// names, therefore top level arguments are left unnamed while tuple components are named argN, as
+// go-ethereum requires every tuple component to be named.
+func ParseSignature(signature string) (string, []MethodArgument, error) {
+	signature = strings.TrimSpace(signature)
+
+	openParenIndex := strings.Index(signature, "(")
+	if openParenIndex <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid signature %q", signature)
+	}
+
+	name := strings.TrimSpace(signature[:openParenIndex])
+
+	arguments, err := parseTupleComponents(signature[openParenIndex+1:len(signature)-1], false)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid signature %q: %w", signature, err)
+	}
+
+	return name, arguments, nil
+}
+
+// parseTupleComponents parses the comma separated list of types found between the parentheses.
+func parseTupleComponents(list string, named bool) ([]MethodArgument, error) {
+	types, err := splitTypes(list)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := make([]MethodArgument, 0, len(types))
+
+	for i, typ := range types {
+		arg, err := parseType(typ)
+		if err != nil {
+			return nil, err
+		}
+
+		arg.Index = i
+		if named {
+			arg.Name = fmt.Sprintf("arg%d", i)
+		}
+
+		toReturn = append(toReturn, arg)
+	}
+
+	return toReturn, nil
+}
+
+// parseType parses the single type which is either elementary (uint256, bytes32[]) or the tuple.
+func parseType(typ string) (MethodArgument, error) {
+	typ = strings.TrimSpace(typ)
+	if strings.HasPrefix(typ, "tuple(") {
+		typ = strings.TrimPrefix(typ, "tuple")
+	}
+
+	if !strings.HasPrefix(typ, "(") {
+		if strings.ContainsAny(typ, "(),") {
+			return MethodArgument{}, fmt.Errorf("invalid type %q", typ)
+		}
+
+		return MethodArgument{Type: typ}, nil
+	}
+
+	closeParenIndex, err := matchingParen(typ)
+	if err != nil {
+		return MethodArgument{}, err
+	}
+
+	suffix := typ[closeParenIndex+1:]
+	if !arraySuffixRegex.MatchString(suffix) {
+		return MethodArgument{}, fmt.Errorf("invalid tuple suffix %q", suffix)
+	}
+
+	components, err := parseTupleComponents(typ[1:closeParenIndex], true)
+	if err != nil {
+		return MethodArgument{}, err
+	}
+
+	if len(components) == 0 {
+		return MethodArgument{}, fmt.Errorf("empty tuple %q", typ)
+	}
+
+	return MethodArgument{
+		Type:       "tuple" + suffix,
+		Components: components,
+	}, nil
+}
+
+// splitTypes splits the list of types on commas which are not nested within the tuple.
+func splitTypes(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	var toReturn []string
+	depth, start := 0, 0
+
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %q", list)
+			}
+		case ',':
+			if depth == 0 {
+				toReturn = append(toReturn, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %q", list)
+	}
+
+	toReturn = append(toReturn, list[start:])
+
+	for _, typ := range toReturn {
+		if strings.TrimSpace(typ) == "" {
+			return nil, fmt.Errorf("empty type in %q", list)
+		}
+	}
+
+	return toReturn, nil
+}
+
+// matchingParen returns the index of the parenthesis closing the one the type starts with.
+func matchingParen(typ string) (int, error) {
+	depth := 0
+
+	for i, c := range typ {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unbalanced parentheses in %q", typ)
+}
+
+// newArgument converts go-ethereum type into the argument, describing tuples with components.
+func newArgument(name string, typ abi.Type, index int) MethodArgument {
+	toReturn := MethodArgument{
+		Name:  name,
+		Type:  typ.String(),
+		Index: index,
+	}
+
+	switch typ.T {
+	case abi.TupleTy:
+		toReturn.Type = "tuple"
+		for i, elem := range typ.TupleElems {
+			componentName := fmt.Sprintf("arg%d", i)
+			if i < len(typ.TupleRawNames) && typ.TupleRawNames[i] != "" {
+				componentName = typ.TupleRawNames[i]
+			}
+
+			toReturn.Components = append(toReturn.Components, newArgument(componentName, *elem, i))
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		elem := newArgument(name, *typ.Elem, index)
+		if len(elem.Components) == 0 {
+			break
+		}
+
+		toReturn.Components = elem.Components
+		if typ.T == abi.SliceTy {
+			toReturn.Type = elem.Type + "[]"
+		} else {
+			toReturn.Type = fmt.Sprintf("%s[%d]", elem.Type, typ.Size)
+		}
+	}
+
+	return toReturn
+}
+
+// newABIType converts the argument back into go-ethereum type. Arguments stored before the
+// components were introduced keep tuples in the signature form, e.g. (address,uint256)[], and
+// are parsed here so that they remain decodable.
+func newABIType(arg MethodArgument) (abi.Type, error) {
+	if len(arg.Components) == 0 && strings.HasPrefix(arg.Type, "(") {
+		parsed, err := parseType(arg.Type)
+		if err != nil {
+			return abi.Type{}, err
+		}
+		arg.Type, arg.Components = parsed.Type, parsed.Components
+	}
+
+	return abi.NewType(arg.Type, "", toArgumentMarshaling(arg.Components))
+}
+
+func toArgumentMarshaling(components []MethodArgument) []abi.ArgumentMarshaling {
+	if len(components) == 0 {
+		return nil
+	}
+
+	toReturn := make([]abi.ArgumentMarshaling, 0, len(components))
+
+	for _, component := range components {
+		toReturn = append(toReturn, abi.ArgumentMarshaling{
+			Name:       component.Name,
+			Type:       component.Type,
+			Components: toArgumentMarshaling(component.Components),
+		})
+	}
+
+	return toReturn
+}
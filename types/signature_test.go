@@ -0,0 +1,97 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignature(t *testing.T) {
+	tAssert := assert.New(t)
+
+	name, arguments, err := ParseSignature("swap((address,uint256)[],bytes)")
+	tAssert.NoError(err)
+	tAssert.Equal("swap", name)
+	tAssert.Len(arguments, 2)
+	tAssert.Equal("tuple[]", arguments[0].Type)
+	tAssert.Equal([]MethodArgument{
+		{Name: "arg0", Type: "address", Index: 0},
+		{Name: "arg1", Type: "uint256", Index: 1},
+	}, arguments[0].Components)
+	tAssert.Equal(MethodArgument{Type: "bytes", Index: 1}, arguments[1])
+
+	name, arguments, err = ParseSignature("nested(uint256,((bool,bytes32[2]),string)[3][],address)")
+	tAssert.NoError(err)
+	tAssert.Equal("nested", name)
+	tAssert.Len(arguments, 3)
+	tAssert.Equal("tuple[3][]", arguments[1].Type)
+	tAssert.Equal("tuple", arguments[1].Components[0].Type)
+	tAssert.Equal("bytes32[2]", arguments[1].Components[0].Components[1].Type)
+	tAssert.Equal(2, arguments[2].Index)
+
+	name, arguments, err = ParseSignature("totalSupply()")
+	tAssert.NoError(err)
+	tAssert.Equal("totalSupply", name)
+	tAssert.Empty(arguments)
+
+	for _, invalid := range []string{"", "foo", "(uint256)", "foo(uint256", "foo((uint256)", "foo(uint256,)", "foo(()[])", "foo((uint256)x)"} {
+		_, _, err := ParseSignature(invalid)
+		tAssert.Error(err, invalid)
+	}
+}
+
+func TestNewFourByteMethod(t *testing.T) {
+	tAssert := assert.New(t)
+
+	method, err := NewFourByteMethod("0x00112233", "swap((address,uint256)[],bytes)")
+	tAssert.NoError(err)
+	tAssert.Equal("swap", method.Name)
+	tAssert.Equal("00112233", method.Hex)
+	tAssert.Equal([]byte{0x00, 0x11, 0x22, 0x33}, method.Bytes)
+	tAssert.Len(method.Arguments, 2)
+
+	arguments, err := method.GetABIArguments()
+	tAssert.NoError(err)
+
+	type pair struct {
+		Arg0 common.Address
+		Arg1 *big.Int
+	}
+
+	pairs := []pair{{Arg0: common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE"), Arg1: big.NewInt(42)}}
+
+	data, err := arguments.Pack(pairs, []byte{0xde, 0xad})
+	tAssert.NoError(err)
+
+	values, err := arguments.Unpack(data)
+	tAssert.NoError(err)
+	tAssert.Len(values, 2)
+	tAssert.Equal([]byte{0xde, 0xad}, values[1])
+}
+
+func TestNewArgumentRoundTrip(t *testing.T) {
+	tAssert := assert.New(t)
+
+	typ, err := abi.NewType("tuple[2]", "", []abi.ArgumentMarshaling{
+		{Name: "token", Type: "address"},
+		{Name: "amounts", Type: "uint256[]"},
+	})
+	tAssert.NoError(err)
+
+	argument := newArgument("orders", typ, 0)
+	tAssert.Equal("tuple[2]", argument.Type)
+	tAssert.Equal("token", argument.Components[0].Name)
+	tAssert.Equal("uint256[]", argument.Components[1].Type)
+
+	parsed, err := newABIType(argument)
+	tAssert.NoError(err)
+	tAssert.Equal(typ.String(), parsed.String())
+
+	// Arguments stored before components were introduced keep tuples in the signature form.
+	legacy, err := newABIType(MethodArgument{Type: "(address,uint256[])[2]"})
+	tAssert.NoError(err)
+	tAssert.Equal(typ.String(), legacy.String())
+}
@@ -15,6 +15,9 @@ const (
 	// databaseMethodKeyPrefix is the prefix used for keys related to methods.
 	databaseMethodKeyPrefix = "methods_______:%s:%s"
 
+	// databaseMethodCandidatesKeyPrefix is the prefix used for keys related to all of the methods sharing the same selector.
+	databaseMethodCandidatesKeyPrefix = "method_candidates_______:%s:%s"
+
 	// databaseMethodMapperKeyPrefix is the prefix used for keys related to method mappers.
 	databaseMethodMapperKeyPrefix = "method_mappers_______:%s:%s"
 
@@ -23,6 +26,12 @@ const (
 
 	// databaseEventMapperKeyPrefix is the prefix used for keys related to event mappers.
 	databaseEventMapperKeyPrefix = "method_event_mapper_______:%s:%s"
+
+	// databaseErrorKeyPrefix is the prefix used for keys related to custom errors.
+	databaseErrorKeyPrefix = "errors_______:%s:%s"
+
+	// databaseTokenDecimalsKeyPrefix is the prefix used for keys related to ERC-20 token decimals.
+	databaseTokenDecimalsKeyPrefix = "token_decimals_______:%s:%s"
 )
 
 // GetContractStorageKeyPrefix returns the prefix used for contract keys in the database.
@@ -50,6 +59,11 @@ func GetEventMapperStorageKeyPrefix() string {
 	return databaseEventMapperKeyPrefix
 }
 
+// GetErrorStorageKeyPrefix returns the prefix used for custom error keys in the database.
+func GetErrorStorageKeyPrefix() string {
+	return databaseErrorKeyPrefix
+}
+
 // GetContractStorageKey generates a key for a contract in the database using the provided chainId and address.
 // The key is generated by appending the hexadecimal representation of the address to the contract key prefix.
 func GetContractStorageKey(chainId *big.Int, addr common.Address) string {
@@ -62,6 +76,12 @@ func GetMethodStorageKey(chainId *big.Int, method []byte) string {
 	return fmt.Sprintf(databaseMethodKeyPrefix, chainId.String(), common.Bytes2Hex(method))
 }
 
+// GetMethodCandidatesStorageKey generates a key for the list of method candidates sharing the provided selector.
+// The key is generated by appending the hexadecimal representation of the selector to the method candidates key prefix.
+func GetMethodCandidatesStorageKey(chainId *big.Int, method []byte) string {
+	return fmt.Sprintf(databaseMethodCandidatesKeyPrefix, chainId.String(), common.Bytes2Hex(method))
+}
+
 // GetMethodMapperStorageKey generates a key for a method mapper in the database using the provided chainId and method.
 // The key is generated by appending the hexadecimal representation of the method to the method mapper key prefix.
 func GetMethodMapperStorageKey(chainId *big.Int, method []byte) string {
@@ -79,3 +99,14 @@ func GetEventStorageKey(chainId *big.Int, event common.Hash) string {
 func GetEventMapperStorageKey(chainId *big.Int, event common.Hash) string {
 	return fmt.Sprintf(databaseEventMapperKeyPrefix, chainId.String(), event.Hex())
 }
+
+// GetErrorStorageKey generates a key for a custom error in the database using the provided chainId and error selector.
+// The key is generated by appending the hexadecimal representation of the selector to the error key prefix.
+func GetErrorStorageKey(chainId *big.Int, selector []byte) string {
+	return fmt.Sprintf(databaseErrorKeyPrefix, chainId.String(), common.Bytes2Hex(selector))
+}
+
+// GetTokenDecimalsStorageKey generates a key for ERC-20 token decimals in the database using the provided chainId and token address.
+func GetTokenDecimalsStorageKey(chainId *big.Int, token common.Address) string {
+	return fmt.Sprintf(databaseTokenDecimalsKeyPrefix, chainId.String(), token.Hex())
+}
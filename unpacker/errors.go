@@ -0,0 +1,23 @@
+package unpacker
+
+import "errors"
+
+var (
+	// ErrCalldataTooShort is returned when calldata does not contain the 4 byte method selector.
+	ErrCalldataTooShort = errors.New("calldata too short to contain method selector")
+
+	// ErrMethodNotFound is returned when method could not be resolved from the ABI nor from any of the readers.
+	ErrMethodNotFound = errors.New("method not found")
+
+	// ErrEventNotFound is returned when event could not be resolved from any of the readers.
+	ErrEventNotFound = errors.New("event not found")
+
+	// ErrAnonymousLog is returned when log has no topics and therefore cannot be matched against any event.
+	ErrAnonymousLog = errors.New("log has no topics")
+
+	// ErrTransactionNotReverted is returned when revert reason is requested for the successful transaction.
+	ErrTransactionNotReverted = errors.New("transaction did not revert")
+
+	// ErrRevertDataNotFound is returned when replaying the failed transaction did not yield any revert data.
+	ErrRevertDataNotFound = errors.New("revert data not found")
+)
@@ -0,0 +1,149 @@
+package unpacker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/helpers"
+	unpacktypes "github.com/txpull/unpack/types"
+	"go.uber.org/zap"
+)
+
+// UnpackLogs fetches all of the receipts for the provided block and decodes every log
+// against the events stored by the crawlers. Logs that could not be decoded are still
+// returned with Decoded set to false and the reason stored in Error.
+func (u *Unpacker) UnpackLogs(chainId *big.Int, blockNumber uint64) ([]*DecodedLog, error) {
+	receipts, err := helpers.GetBlockReceipts(u.ctx, u.ethClient, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block receipts: %w", err)
+	}
+
+	// Logs of the whole block are decoded at once so that events are looked up with a single batch.
+	var logs []*types.Log
+	for _, receipt := range receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+
+	return u.decodeLogs(chainId, logs), nil
+}
+
+// decodeLogs decodes provided logs against the events stored in the readers.
+func (u *Unpacker) decodeLogs(chainId *big.Int, logs []*types.Log) []*DecodedLog {
+	toReturn := make([]*DecodedLog, 0, len(logs))
+	events := u.lookupEvents(chainId, logs)
+
+	for _, log := range logs {
+		decoded := &DecodedLog{
+			Address:          log.Address,
+			BlockNumber:      log.BlockNumber,
+			BlockHash:        log.BlockHash,
+			TransactionHash:  log.TxHash,
+			TransactionIndex: log.TxIndex,
+			LogIndex:         log.Index,
+			Topics:           log.Topics,
+			Data:             log.Data,
+		}
+
+		event, err := u.decodeEvent(log, events)
+		if err != nil {
+			decoded.Error = err.Error()
+		} else {
+			decoded.Decoded = true
+			decoded.Event = event
+		}
+
+		toReturn = append(toReturn, decoded)
+	}
+
+	return toReturn
+}
+
+// lookupEvents fetches events matching topics[0] of the logs with a single batch lookup.
+func (u *Unpacker) lookupEvents(chainId *big.Int, logs []*types.Log) map[common.Hash]*unpacktypes.Event {
+	var hashes []common.Hash
+	seen := make(map[common.Hash]bool)
+	for _, log := range logs {
+		if len(log.Topics) > 0 && !seen[log.Topics[0]] {
+			seen[log.Topics[0]] = true
+			hashes = append(hashes, log.Topics[0])
+		}
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	events, err := u.reader.GetEventsByHashes(u.ctx, chainId, hashes)
+	if err != nil {
+		zap.L().Error(
+			"failed to get events from the readers",
+			zap.Int64("chain_id", chainId.Int64()),
+			zap.Int("hashes", len(hashes)),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return events
+}
+
+// decodeEvent resolves the event by topics[0] and unpacks indexed and non-indexed arguments.
+func (u *Unpacker) decodeEvent(log *types.Log, events map[common.Hash]*unpacktypes.Event) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrAnonymousLog
+	}
+
+	if event, ok := events[log.Topics[0]]; ok {
+		arguments, err := event.GetABIArguments()
+		if err != nil {
+			return nil, err
+		}
+
+		if event.IsPartial {
+			arguments = inferIndexedArguments(arguments, len(log.Topics)-1)
+		}
+
+		decoded, err := abis.DecodeEventArguments(arguments, log.Topics[1:], log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack event %s arguments: %w", event.Signature, err)
+		}
+
+		return &DecodedEvent{
+			Hash:      log.Topics[0],
+			Name:      event.Name,
+			Signature: event.Signature,
+			IsPartial: event.IsPartial,
+			Arguments: decoded,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrEventNotFound, log.Topics[0].Hex())
+}
+
+// inferIndexedArguments marks leading arguments of the partial event as indexed. Event signatures
+// (e.g. from 4byte.directory) do not say which arguments are indexed, however indexed arguments
+// are by convention declared first, e.g. Transfer(address indexed,address indexed,uint256).
+func inferIndexedArguments(arguments abi.Arguments, indexed int) abi.Arguments {
+	for _, arg := range arguments {
+		if arg.Indexed {
+			return arguments
+		}
+	}
+
+	if indexed <= 0 || indexed > len(arguments) {
+		return arguments
+	}
+
+	toReturn := make(abi.Arguments, len(arguments))
+	copy(toReturn, arguments)
+
+	for i := 0; i < indexed; i++ {
+		toReturn[i].Indexed = true
+	}
+
+	return toReturn
+}
@@ -0,0 +1,105 @@
+package unpacker
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/readers"
+	"github.com/txpull/unpack/types"
+)
+
+func TestUnpacker_InferIndexedArguments(t *testing.T) {
+	tAssert := assert.New(t)
+
+	event, err := types.NewFourByteEvent(
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"Transfer(address,address,uint256)",
+	)
+	tAssert.NoError(err)
+	tAssert.True(event.IsPartial)
+
+	arguments, err := event.GetABIArguments()
+	tAssert.NoError(err)
+
+	inferred := inferIndexedArguments(arguments, 2)
+	tAssert.True(inferred[0].Indexed)
+	tAssert.True(inferred[1].Indexed)
+	tAssert.False(inferred[2].Indexed)
+
+	// Original arguments are left untouched.
+	tAssert.False(arguments[0].Indexed)
+
+	tAssert.Equal(arguments, inferIndexedArguments(arguments, 4))
+}
+
+// eventsReader serves the stored events and records the hashes each batch lookup asked for.
+type eventsReader struct {
+	readers.MockReader
+	events  map[common.Hash]*types.Event
+	batches [][]common.Hash
+}
+
+func (r *eventsReader) GetEventsByHashes(ctx context.Context, chainId *big.Int, hashes []common.Hash) (map[common.Hash]*types.Event, error) {
+	r.batches = append(r.batches, append([]common.Hash{}, hashes...))
+
+	toReturn := make(map[common.Hash]*types.Event)
+	for _, hash := range hashes {
+		if event, ok := r.events[hash]; ok {
+			toReturn[hash] = event
+		}
+	}
+	return toReturn, nil
+}
+
+func TestUnpacker_LookupEvents(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+
+	transfer, err := types.NewFourByteEvent(
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"Transfer(address,address,uint256)",
+	)
+	tAssert.NoError(err)
+
+	approval, err := types.NewFourByteEvent(
+		"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+		"Approval(address,address,uint256)",
+	)
+	tAssert.NoError(err)
+
+	unknown := common.HexToHash("0x01")
+
+	fast := &eventsReader{events: map[common.Hash]*types.Event{transfer.Hash: transfer}}
+	slow := &eventsReader{events: map[common.Hash]*types.Event{approval.Hash: approval}}
+
+	manager, err := readers.NewManager(ctx,
+		readers.WithReader("fast", fast),
+		readers.WithReader("slow", slow),
+		readers.WithPriorityReader("fast"),
+	)
+	tAssert.NoError(err)
+
+	unpacker := &Unpacker{ctx: ctx, reader: manager}
+
+	logs := []*ethtypes.Log{
+		{Topics: []common.Hash{transfer.Hash}},
+		{Topics: []common.Hash{approval.Hash}},
+		{Topics: []common.Hash{transfer.Hash}},
+		{Topics: []common.Hash{unknown}},
+		{},
+	}
+
+	events := unpacker.lookupEvents(big.NewInt(56), logs)
+	tAssert.Len(events, 2)
+	tAssert.Equal(transfer, events[transfer.Hash])
+	tAssert.Equal(approval, events[approval.Hash])
+
+	// Every reader is asked once, the slower one only for the hashes the faster one did not have.
+	tAssert.Equal([][]common.Hash{{transfer.Hash, approval.Hash, unknown}}, fast.batches)
+	tAssert.Equal([][]common.Hash{{approval.Hash, unknown}}, slow.batches)
+}
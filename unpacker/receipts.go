@@ -0,0 +1,52 @@
+package unpacker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/txpull/unpack/helpers"
+)
+
+// UnpackReceipt fetches all of the receipts for the provided block and decodes them,
+// including status, gas usage, created contract address and decoded logs.
+func (u *Unpacker) UnpackReceipt(chainId *big.Int, blockNumber uint64) ([]*DecodedReceipt, error) {
+	receipts, err := helpers.GetBlockReceipts(u.ctx, u.ethClient, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block receipts: %w", err)
+	}
+
+	toReturn := make([]*DecodedReceipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		toReturn = append(toReturn, u.DecodeReceipt(chainId, receipt))
+	}
+
+	return toReturn, nil
+}
+
+// DecodeReceipt decodes already fetched receipt. It does not reach the node, so it can be used
+// offline, for example against receipts loaded with fixtures.EthReader.
+func (u *Unpacker) DecodeReceipt(chainId *big.Int, receipt *types.Receipt) *DecodedReceipt {
+	toReturn := &DecodedReceipt{
+		TransactionHash:   receipt.TxHash,
+		TransactionIndex:  receipt.TransactionIndex,
+		BlockHash:         receipt.BlockHash,
+		Type:              receipt.Type,
+		Status:            receipt.Status,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+		Logs:              u.decodeLogs(chainId, receipt.Logs),
+	}
+
+	if receipt.BlockNumber != nil {
+		toReturn.BlockNumber = receipt.BlockNumber.Uint64()
+	}
+
+	if receipt.ContractAddress != helpers.ZeroAddress {
+		contractAddress := receipt.ContractAddress
+		toReturn.ContractAddress = &contractAddress
+	}
+
+	return toReturn
+}
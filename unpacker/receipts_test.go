@@ -0,0 +1,57 @@
+package unpacker
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/readers"
+)
+
+func TestUnpacker_DecodeReceipt(t *testing.T) {
+	tAssert := assert.New(t)
+
+	ctx := context.TODO()
+
+	manager, err := readers.NewManager(ctx, readers.WithReader("mock", &readers.MockReader{}))
+	tAssert.NoError(err)
+
+	unpacker := &Unpacker{ctx: ctx, reader: manager}
+
+	txHash := common.HexToHash("0x01")
+	contractAddress := common.HexToAddress("0x33fDd11397Bf41CceA71572db4C2AE2F276f84EE")
+
+	receipt := &types.Receipt{
+		Type:              types.DynamicFeeTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 100000,
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(5000000000),
+		TxHash:            txHash,
+		ContractAddress:   contractAddress,
+		BlockNumber:       big.NewInt(100),
+		Logs: []*types.Log{
+			{Address: contractAddress, Topics: []common.Hash{common.HexToHash("0x02")}, TxHash: txHash},
+			{Address: contractAddress, TxHash: txHash},
+		},
+	}
+
+	decoded := unpacker.DecodeReceipt(big.NewInt(56), receipt)
+	tAssert.Equal(types.ReceiptStatusSuccessful, decoded.Status)
+	tAssert.Equal(uint64(21000), decoded.GasUsed)
+	tAssert.Equal(uint64(100), decoded.BlockNumber)
+	tAssert.Equal(big.NewInt(5000000000), decoded.EffectiveGasPrice)
+	tAssert.Equal(&contractAddress, decoded.ContractAddress)
+	tAssert.Len(decoded.Logs, 2)
+
+	for _, log := range decoded.Logs {
+		tAssert.False(log.Decoded)
+		tAssert.NotEmpty(log.Error)
+	}
+
+	receipt.ContractAddress = common.Address{}
+	tAssert.Nil(unpacker.DecodeReceipt(big.NewInt(56), receipt).ContractAddress)
+}
@@ -0,0 +1,74 @@
+package unpacker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/helpers"
+)
+
+// UnpackRevert decodes the revert reason of the failed transaction. Receipts do not carry revert data,
+// so the transaction is replayed with eth_call on top of the parent block state. Transactions that
+// depend on state changed earlier in the same block may therefore revert with a different reason or
+// not revert at all, in which case ErrRevertDataNotFound is returned.
+func (u *Unpacker) UnpackRevert(chainId *big.Int, txHash common.Hash) (*abis.Revert, error) {
+	tx, _, err := helpers.GetTransactionByHash(u.ctx, u.ethClient, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by hash: %w", err)
+	}
+
+	receipt, err := helpers.GetReceiptByHash(u.ctx, u.ethClient, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return nil, ErrTransactionNotReverted
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainId), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	parentBlock := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+
+	_, err = u.ethClient.GetClient().CallContract(u.ctx, msg, parentBlock)
+	if err == nil {
+		return nil, ErrRevertDataNotFound
+	}
+
+	data, ok := helpers.GetRevertData(err)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRevertDataNotFound, err)
+	}
+
+	return u.decodeRevert(chainId, tx.To(), data)
+}
+
+// decodeRevert decodes revert data against the ABI of the contract that reverted, falling back to the stored errors.
+func (u *Unpacker) decodeRevert(chainId *big.Int, addr *common.Address, data []byte) (*abis.Revert, error) {
+	var contractAbi *abi.ABI
+
+	if addr != nil {
+		if contract, err := u.contractDecoder.DecodeAbiByAddress(chainId, *addr); err == nil && contract != nil && contract.Abi != nil {
+			parsedAbi := contract.Abi.GetABI()
+			contractAbi = &parsedAbi
+		}
+	}
+
+	return abis.DecodeRevert(u.ctx, chainId, data, contractAbi, u.reader)
+}
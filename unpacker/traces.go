@@ -0,0 +1,139 @@
+package unpacker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/helpers"
+	"go.uber.org/zap"
+)
+
+// UnpackTrace traces all of the transactions in the block with the callTracer and decodes
+// the input and output of every call in the resulting call trees against the callee ABI.
+func (u *Unpacker) UnpackTrace(chainId *big.Int, blockNumber uint64) ([]*DecodedTrace, error) {
+	traces, err := helpers.TraceBlockByNumber(u.ctx, u.ethClient, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace block: %w", err)
+	}
+
+	toReturn := make([]*DecodedTrace, 0, len(traces))
+	for _, trace := range traces {
+		decoded := &DecodedTrace{
+			TransactionHash: trace.TxHash,
+			Error:           trace.Error,
+		}
+
+		if trace.Result != nil {
+			decoded.Call = u.DecodeCallFrame(chainId, trace.Result)
+		}
+
+		toReturn = append(toReturn, decoded)
+	}
+
+	return toReturn, nil
+}
+
+// UnpackTransactionTrace traces a single transaction with the callTracer and decodes its call tree.
+func (u *Unpacker) UnpackTransactionTrace(chainId *big.Int, txHash common.Hash) (*DecodedTrace, error) {
+	frame, err := helpers.TraceTransaction(u.ctx, u.ethClient, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace transaction: %w", err)
+	}
+
+	return &DecodedTrace{
+		TransactionHash: txHash,
+		Call:            u.DecodeCallFrame(chainId, frame),
+	}, nil
+}
+
+// DecodeCallFrame recursively decodes already fetched callTracer frame.
+func (u *Unpacker) DecodeCallFrame(chainId *big.Int, frame *helpers.CallFrame) *DecodedCall {
+	toReturn := &DecodedCall{
+		Type:         frame.Type,
+		From:         frame.From,
+		To:           frame.To,
+		Gas:          uint64(frame.Gas),
+		GasUsed:      uint64(frame.GasUsed),
+		Input:        frame.Input,
+		Output:       frame.Output,
+		Error:        frame.Error,
+		RevertReason: frame.RevertReason,
+	}
+
+	if frame.Value != nil {
+		toReturn.Value = frame.Value.ToInt()
+	}
+
+	// Contract creation input is the init code, not the method calldata.
+	if frame.To != nil && !isCreateCall(frame.Type) && len(frame.Input) >= 4 {
+		u.decodeCall(chainId, toReturn)
+	}
+
+	// Failed calls return revert data as the output.
+	if frame.Error != "" && len(frame.Output) > 0 {
+		revert, err := u.decodeRevert(chainId, frame.To, frame.Output)
+		if err != nil {
+			zap.L().Debug(
+				"failed to decode call revert",
+				zap.String("from", frame.From.Hex()),
+				zap.Error(err),
+			)
+		} else {
+			toReturn.Revert = revert
+		}
+	}
+
+	for i := range frame.Calls {
+		toReturn.Calls = append(toReturn.Calls, u.DecodeCallFrame(chainId, &frame.Calls[i]))
+	}
+
+	return toReturn
+}
+
+// decodeCall decodes call input and, for successful calls, its output.
+func (u *Unpacker) decodeCall(chainId *big.Int, call *DecodedCall) {
+	method, err := u.resolveMethod(chainId, *call.To, call.Input)
+	if err != nil {
+		zap.L().Debug(
+			"failed to resolve call method",
+			zap.String("address", call.To.Hex()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	decoded, err := method.decode(call.Input[4:])
+	if err != nil {
+		zap.L().Debug(
+			"failed to decode call input",
+			zap.String("address", call.To.Hex()),
+			zap.String("signature", method.signature),
+			zap.Error(err),
+		)
+		return
+	}
+	call.Method = decoded
+
+	if call.Error != "" || len(call.Output) == 0 || len(method.outputs) == 0 {
+		return
+	}
+
+	outputs, err := abis.DecodeArguments(method.outputs, call.Output)
+	if err != nil {
+		zap.L().Debug(
+			"failed to decode call output",
+			zap.String("address", call.To.Hex()),
+			zap.String("signature", method.signature),
+			zap.Error(err),
+		)
+		return
+	}
+	call.Outputs = outputs
+}
+
+// isCreateCall reports whether callTracer frame type represents contract creation.
+func isCreateCall(callType string) bool {
+	return callType == "CREATE" || callType == "CREATE2"
+}
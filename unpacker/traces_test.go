@@ -0,0 +1,106 @@
+package unpacker
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/contracts"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/types"
+)
+
+func TestUnpacker_DecodeCallFrame(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+
+	token := common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56")
+	unverified := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+
+	abiDecoder, err := abis.NewDecoder(context.TODO(), nil, erc20TransferAbi)
+	tAssert.NoError(err)
+
+	unpacker := newTestUnpacker(t,
+		&candidatesReader{candidates: map[string]types.Methods{
+			"a9059cbb": {newFourByteMethod(t, "transfer(address,uint256)", 145)},
+		}},
+		&contractsDecoder{contracts: map[common.Address]*contracts.ContractResponse{
+			token: {Address: token, Abi: abiDecoder},
+		}},
+	)
+
+	// Router calls the verified token, which calls the unverified one that reverts with Error("insufficient balance").
+	transfer := "0xa9059cbb" +
+		"0000000000000000000000008894e0a0c962cb723c1976a4421c95949be2d4e3" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	revert := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"696e73756666696369656e742062616c616e6365000000000000000000000000"
+
+	raw := `{
+		"type": "CALL",
+		"from": "0x10ed43c718714eb63d5aa57b78b54704e256024e",
+		"to": "` + token.Hex() + `",
+		"value": "0x0",
+		"gas": "0x7530",
+		"gasUsed": "0x5208",
+		"input": "` + transfer + `",
+		"output": "0x0000000000000000000000000000000000000000000000000000000000000001",
+		"calls": [{
+			"type": "CALL",
+			"from": "` + token.Hex() + `",
+			"to": "` + unverified.Hex() + `",
+			"gas": "0x4e20",
+			"gasUsed": "0x1388",
+			"input": "` + transfer + `",
+			"output": "` + revert + `",
+			"error": "execution reverted",
+			"revertReason": "insufficient balance"
+		}, {
+			"type": "CREATE",
+			"from": "` + token.Hex() + `",
+			"to": "0x0000000000000000000000000000000000000001",
+			"gas": "0x4e20",
+			"gasUsed": "0x1388",
+			"input": "0x6080604052"
+		}]
+	}`
+
+	frame := &helpers.CallFrame{}
+	tAssert.NoError(json.Unmarshal([]byte(raw), frame))
+
+	call := unpacker.DecodeCallFrame(chainId, frame)
+
+	tAssert.Equal("CALL", call.Type)
+	tAssert.Equal(uint64(30000), call.Gas)
+	tAssert.Equal(uint64(21000), call.GasUsed)
+	tAssert.Zero(call.Value.Sign())
+	tAssert.NotNil(call.Method)
+	tAssert.Equal("transfer(address,uint256)", call.Method.Signature)
+	tAssert.False(call.Method.IsPartial)
+	tAssert.Len(call.Method.Arguments, 2)
+	tAssert.Len(call.Outputs, 1)
+	tAssert.Equal(true, call.Outputs[0].Value)
+	tAssert.Nil(call.Revert)
+	tAssert.Len(call.Calls, 2)
+
+	// Reverted child is decoded against the 4byte method, its output is the revert data instead of return values.
+	child := call.Calls[0]
+	tAssert.NotNil(child.Method)
+	tAssert.True(child.Method.IsPartial)
+	tAssert.Empty(child.Outputs)
+	tAssert.Equal("execution reverted", child.Error)
+	tAssert.Equal("insufficient balance", child.RevertReason)
+	tAssert.NotNil(child.Revert)
+	tAssert.Equal(abis.RevertKindError, child.Revert.Kind)
+	tAssert.Equal("insufficient balance", child.Revert.Message)
+
+	// Contract creation input is the init code and is never decoded as the method calldata.
+	tAssert.Nil(call.Calls[1].Method)
+}
@@ -0,0 +1,192 @@
+package unpacker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/readers"
+	"go.uber.org/zap"
+)
+
+// UnpackTransaction fetches the transaction by its hash and decodes its calldata.
+// Calldata is decoded against the verified contract ABI when available, otherwise
+// against the 4byte method stored for the selector. When neither is available the
+// transaction is returned without the decoded method.
+func (u *Unpacker) UnpackTransaction(chainId *big.Int, txHash common.Hash) (*DecodedTransaction, error) {
+	tx, pending, err := helpers.GetTransactionByHash(u.ctx, u.ethClient, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by hash: %w", err)
+	}
+
+	return u.unpackTransaction(chainId, tx, pending)
+}
+
+func (u *Unpacker) unpackTransaction(chainId *big.Int, tx *types.Transaction, pending bool) (*DecodedTransaction, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(chainId), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+
+	toReturn := &DecodedTransaction{
+		ChainID:            chainId,
+		Hash:               tx.Hash(),
+		Type:               tx.Type(),
+		Pending:            pending,
+		From:               from,
+		To:                 tx.To(),
+		Nonce:              tx.Nonce(),
+		Value:              tx.Value(),
+		Gas:                tx.Gas(),
+		GasPrice:           tx.GasPrice(),
+		GasTipCap:          tx.GasTipCap(),
+		GasFeeCap:          tx.GasFeeCap(),
+		Input:              tx.Data(),
+		IsContractCreation: tx.To() == nil,
+	}
+
+	// Contract creation and plain value transfers do not carry any method calldata.
+	if toReturn.IsContractCreation || len(tx.Data()) < 4 {
+		return toReturn, nil
+	}
+
+	method, err := u.decodeMethod(chainId, *tx.To(), tx.Data())
+	if err != nil {
+		zap.L().Debug(
+			"failed to decode transaction method",
+			zap.String("tx_hash", tx.Hash().Hex()),
+			zap.Error(err),
+		)
+		return toReturn, nil
+	}
+
+	toReturn.Method = method
+	return toReturn, nil
+}
+
+// resolvedMethod holds method definition resolved either from the contract ABI or from the 4byte methods.
+type resolvedMethod struct {
+	selector  string
+	name      string
+	signature string
+	isPartial bool
+	inputs    abi.Arguments
+	outputs   abi.Arguments
+
+	// alternatives holds less likely 4byte methods sharing the selector that decode the calldata as well.
+	alternatives []abis.MethodCandidate
+}
+
+// decode unpacks calldata (without the selector) against the resolved method inputs.
+func (m *resolvedMethod) decode(data []byte) (*DecodedMethod, error) {
+	arguments, err := abis.DecodeArguments(m.inputs, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack method %s arguments: %w", m.signature, err)
+	}
+
+	toReturn := &DecodedMethod{
+		Selector:  m.selector,
+		Name:      m.name,
+		Signature: m.signature,
+		IsPartial: m.isPartial,
+		Arguments: arguments,
+	}
+
+	for _, alternative := range m.alternatives {
+		toReturn.Alternatives = append(toReturn.Alternatives, &DecodedMethod{
+			Selector:  m.selector,
+			Name:      alternative.Method.Name,
+			Signature: alternative.Method.Signature,
+			IsPartial: alternative.Method.IsPartial,
+			Arguments: alternative.Arguments,
+		})
+	}
+
+	return toReturn, nil
+}
+
+// decodeMethod decodes calldata against the contract ABI, falling back to the 4byte methods.
+func (u *Unpacker) decodeMethod(chainId *big.Int, addr common.Address, data []byte) (*DecodedMethod, error) {
+	method, err := u.resolveMethod(chainId, addr, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.decode(data[4:])
+}
+
+// resolveMethod resolves method matching calldata selector from the contract ABI, falling back to the 4byte methods.
+func (u *Unpacker) resolveMethod(chainId *big.Int, addr common.Address, data []byte) (*resolvedMethod, error) {
+	if len(data) < 4 {
+		return nil, ErrCalldataTooShort
+	}
+
+	contract, err := u.contractDecoder.DecodeAbiByAddress(chainId, addr)
+	if err != nil {
+		zap.L().Debug(
+			"failed to resolve contract, falling back to 4byte methods",
+			zap.String("address", addr.Hex()),
+			zap.Error(err),
+		)
+	}
+
+	// Partial ABI of the unverified contract holds a single arbitrary method per selector, every candidate
+	// sharing the selector is ranked against the calldata instead.
+	if contract != nil && contract.Abi != nil && !contract.HasPartialAbi() {
+		contractAbi := contract.Abi.GetABI()
+		if method, err := contractAbi.MethodById(data[:4]); err == nil {
+			return &resolvedMethod{
+				selector:  common.Bytes2Hex(method.ID),
+				name:      method.Name,
+				signature: method.Sig,
+				inputs:    method.Inputs,
+				outputs:   method.Outputs,
+			}, nil
+		}
+	}
+
+	return u.resolvePartialMethod(chainId, data)
+}
+
+// resolvePartialMethod resolves the 4byte method matching the calldata selector. All of the methods sharing
+// the selector are tried against the calldata and the most likely one is returned, the rest are kept as alternatives.
+func (u *Unpacker) resolvePartialMethod(chainId *big.Int, data []byte) (*resolvedMethod, error) {
+	selector := common.Bytes2Hex(data[:4])
+
+	candidates, err := u.reader.GetMethodCandidates(u.ctx, chainId, selector)
+	if err != nil && !errors.Is(err, readers.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get method candidates for %s: %w", selector, err)
+	}
+
+	ranked := abis.RankMethodCandidates(candidates, data[4:])
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMethodNotFound, selector)
+	}
+
+	method := ranked[0].Method
+
+	inputs, err := method.GetABIArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := method.GetABIReturns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &resolvedMethod{
+		selector:     selector,
+		name:         method.Name,
+		signature:    method.Signature,
+		isPartial:    method.IsPartial,
+		inputs:       inputs,
+		outputs:      outputs,
+		alternatives: ranked[1:],
+	}, nil
+}
@@ -0,0 +1,303 @@
+package unpacker
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/abis"
+	"github.com/txpull/unpack/contracts"
+	"github.com/txpull/unpack/readers"
+	"github.com/txpull/unpack/types"
+)
+
+const erc20TransferAbi = `[{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// candidatesReader serves the stored method candidates by selector, or fails every lookup with the err.
+type candidatesReader struct {
+	readers.MockReader
+	candidates map[string]types.Methods
+	err        error
+}
+
+func (r *candidatesReader) GetMethodCandidates(ctx context.Context, chainId *big.Int, selector string) (types.Methods, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if candidates, ok := r.candidates[selector]; ok {
+		return candidates, nil
+	}
+
+	return nil, readers.ErrRecordNotFound
+}
+
+// contractsDecoder serves the stored contract responses by address.
+type contractsDecoder struct {
+	contracts map[common.Address]*contracts.ContractResponse
+}
+
+func (d *contractsDecoder) DecodeByAddress(chainId *big.Int, addr common.Address, abi *abis.Decoder) (*contracts.ContractResponse, error) {
+	if contract, ok := d.contracts[addr]; ok {
+		return contract, nil
+	}
+
+	return nil, nil
+}
+
+func (d *contractsDecoder) DecodeAbiByAddress(chainId *big.Int, addr common.Address) (*contracts.ContractResponse, error) {
+	return d.DecodeByAddress(chainId, addr, nil)
+}
+
+func newFourByteMethod(t *testing.T, signature string, fourByteID int64) *types.Method {
+	method, err := types.NewFourByteMethod("0xa9059cbb", signature)
+	if err != nil {
+		t.Fatalf("failed to create 4byte method %s: %s", signature, err)
+	}
+	method.FourByteID = fourByteID
+	return method
+}
+
+func newTestUnpacker(t *testing.T, reader readers.Reader, decoder addressDecoder) *Unpacker {
+	ctx := context.TODO()
+
+	manager, err := readers.NewManager(ctx, readers.WithReader("candidates", reader))
+	if err != nil {
+		t.Fatalf("failed to create readers manager: %s", err)
+	}
+
+	return &Unpacker{ctx: ctx, reader: manager, contractDecoder: decoder}
+}
+
+func TestUnpacker_UnpackTransaction(t *testing.T) {
+	chainId := big.NewInt(56)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+	wallet := common.HexToAddress("0x8894E0a0c962CB723c1976a4421c95949bE2D4E3")
+
+	transfer := common.FromHex("0xa9059cbb")
+	transfer = append(transfer, common.LeftPadBytes(wallet.Bytes(), 32)...)
+	transfer = append(transfer, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)...)
+
+	reader := &candidatesReader{candidates: map[string]types.Methods{
+		"a9059cbb": {
+			newFourByteMethod(t, "many_msg_babbage(bytes1)", 300000),
+			newFourByteMethod(t, "sweep(address,uint256)", 9000),
+			newFourByteMethod(t, "transfer(address,uint256)", 145),
+		},
+	}}
+
+	abiDecoder, err := abis.NewDecoder(context.TODO(), nil, erc20TransferAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Partial ABI holds the single arbitrary method of the selector, matching it would skip the ranking
+	// and report the method as verified without the alternatives.
+	partialAbi, err := abis.NewPartialDecoder(context.TODO(), nil, []*types.Method{newFourByteMethod(t, "transfer(address,uint256)", 145)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verified := common.HexToAddress("0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56")
+	unverified := common.HexToAddress("0x0E09FaBB73Bd3Ade0a17ECC321fD13a19e81cE82")
+	proxy := common.HexToAddress("0x2170Ed0880ac9A755fd29B2688956BD959F933F8")
+	decoder := &contractsDecoder{contracts: map[common.Address]*contracts.ContractResponse{
+		verified:   {Address: verified, Abi: abiDecoder},
+		unverified: {Address: unverified, Abi: partialAbi, IsPartial: true},
+		// Verified proxy delegating to the unverified implementation decodes calls against its partial ABI.
+		proxy: {
+			Address:        proxy,
+			Abi:            partialAbi,
+			ProxyAbi:       abiDecoder,
+			Implementation: &contracts.ContractResponse{Address: unverified, Abi: partialAbi, IsPartial: true},
+		},
+	}}
+
+	tests := []struct {
+		name              string
+		to                *common.Address
+		data              []byte
+		expectedMethod    string
+		expectedPartial   bool
+		expectedAlts      []string
+		expectedCreation  bool
+		expectedArguments int
+	}{
+		{
+			name:              "verified contract abi",
+			to:                &verified,
+			data:              transfer,
+			expectedMethod:    "transfer(address,uint256)",
+			expectedArguments: 2,
+		},
+		{
+			name:              "4byte candidates fallback",
+			to:                &token,
+			data:              transfer,
+			expectedMethod:    "transfer(address,uint256)",
+			expectedPartial:   true,
+			expectedAlts:      []string{"sweep(address,uint256)"},
+			expectedArguments: 2,
+		},
+		{
+			name:              "unverified contract partial abi",
+			to:                &unverified,
+			data:              transfer,
+			expectedMethod:    "transfer(address,uint256)",
+			expectedPartial:   true,
+			expectedAlts:      []string{"sweep(address,uint256)"},
+			expectedArguments: 2,
+		},
+		{
+			name:              "proxy with unverified implementation",
+			to:                &proxy,
+			data:              transfer,
+			expectedMethod:    "transfer(address,uint256)",
+			expectedPartial:   true,
+			expectedAlts:      []string{"sweep(address,uint256)"},
+			expectedArguments: 2,
+		},
+		{
+			name: "calldata shorter than selector",
+			to:   &token,
+			data: common.FromHex("0xa905"),
+		},
+		{
+			name: "unknown selector",
+			to:   &token,
+			data: common.FromHex("0xdeadbeef"),
+		},
+		{
+			name:             "contract creation",
+			data:             common.FromHex("0x6080604052"),
+			expectedCreation: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tAssert := assert.New(t)
+
+			unpacker := newTestUnpacker(t, reader, decoder)
+
+			tx, err := ethtypes.SignTx(
+				ethtypes.NewTx(&ethtypes.LegacyTx{Nonce: 1, To: test.to, Gas: 21000, GasPrice: big.NewInt(1), Data: test.data}),
+				ethtypes.LatestSignerForChainID(chainId),
+				key,
+			)
+			tAssert.NoError(err)
+
+			decoded, err := unpacker.unpackTransaction(chainId, tx, false)
+			tAssert.NoError(err)
+			tAssert.Equal(crypto.PubkeyToAddress(key.PublicKey), decoded.From)
+			tAssert.Equal(test.expectedCreation, decoded.IsContractCreation)
+
+			if test.expectedMethod == "" {
+				tAssert.Nil(decoded.Method)
+				return
+			}
+
+			tAssert.NotNil(decoded.Method)
+			tAssert.Equal("a9059cbb", decoded.Method.Selector)
+			tAssert.Equal(test.expectedMethod, decoded.Method.Signature)
+			tAssert.Equal(test.expectedPartial, decoded.Method.IsPartial)
+			tAssert.Len(decoded.Method.Arguments, test.expectedArguments)
+
+			alternatives := make([]string, 0, len(decoded.Method.Alternatives))
+			for _, alternative := range decoded.Method.Alternatives {
+				alternatives = append(alternatives, alternative.Signature)
+			}
+			tAssert.ElementsMatch(test.expectedAlts, alternatives)
+		})
+	}
+}
+
+func TestUnpacker_DecodeMethod(t *testing.T) {
+	tAssert := assert.New(t)
+
+	chainId := big.NewInt(56)
+	token := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+
+	unpacker := newTestUnpacker(t, &candidatesReader{}, &contractsDecoder{})
+
+	_, err := unpacker.decodeMethod(chainId, token, common.FromHex("0xa905"))
+	tAssert.ErrorIs(err, ErrCalldataTooShort)
+
+	_, err = unpacker.decodeMethod(chainId, token, common.FromHex("0xa9059cbb"))
+	tAssert.ErrorIs(err, ErrMethodNotFound)
+}
+
+func TestUnpacker_ResolvePartialMethod(t *testing.T) {
+	chainId := big.NewInt(56)
+
+	// Calldata of transfer(address,uint256), which every (address,uint256) candidate below decodes.
+	calldata := common.FromHex("0xa9059cbb")
+	calldata = append(calldata, common.LeftPadBytes(common.HexToAddress("0x01").Bytes(), 32)...)
+	calldata = append(calldata, common.LeftPadBytes(big.NewInt(1).Bytes(), 32)...)
+
+	tests := []struct {
+		name          string
+		reader        *candidatesReader
+		expectedName  string
+		expectedError error
+	}{
+		{
+			name: "oldest matching candidate wins",
+			reader: &candidatesReader{candidates: map[string]types.Methods{
+				"a9059cbb": {
+					newFourByteMethod(t, "sweep(address,uint256)", 9000),
+					newFourByteMethod(t, "transfer(address,uint256)", 145),
+				},
+			}},
+			expectedName: "transfer",
+		},
+		{
+			name: "candidates not matching calldata",
+			reader: &candidatesReader{candidates: map[string]types.Methods{
+				"a9059cbb": {newFourByteMethod(t, "many_msg_babbage(bytes1)", 300000)},
+			}},
+			expectedError: ErrMethodNotFound,
+		},
+		{
+			name:          "no candidates",
+			reader:        &candidatesReader{},
+			expectedError: ErrMethodNotFound,
+		},
+		{
+			name:          "reader failure",
+			reader:        &candidatesReader{err: errors.New("connection refused")},
+			expectedError: readers.ErrReadersFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tAssert := assert.New(t)
+
+			unpacker := newTestUnpacker(t, test.reader, &contractsDecoder{})
+
+			method, err := unpacker.resolvePartialMethod(chainId, calldata)
+			if test.expectedError != nil {
+				tAssert.ErrorIs(err, test.expectedError)
+				tAssert.Nil(method)
+				return
+			}
+
+			tAssert.NoError(err)
+			tAssert.Equal(test.expectedName, method.name)
+			tAssert.True(method.isPartial)
+			tAssert.Equal("a9059cbb", method.selector)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package unpacker
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/abis"
+)
+
+// DecodedMethod represents the method resolved from the transaction calldata.
+type DecodedMethod struct {
+	// Selector is the hex encoded 4 byte method selector without 0x prefix.
+	Selector string `json:"selector"`
+
+	// Name is the name of the method.
+	Name string `json:"name"`
+
+	// Signature is the canonical method signature, e.g. transfer(address,uint256).
+	Signature string `json:"signature"`
+
+	// IsPartial is set when the method was resolved through 4byte signatures instead of the verified contract ABI.
+	IsPartial bool `json:"is_partial"`
+
+	// Arguments holds decoded calldata arguments.
+	Arguments []abis.Value `json:"arguments"`
+
+	// Alternatives holds other 4byte methods sharing the selector that decode the calldata as well,
+	// ranked by likelihood. It is empty for methods resolved from the verified contract ABI.
+	Alternatives []*DecodedMethod `json:"alternatives,omitempty"`
+}
+
+// DecodedTransaction represents a transaction together with its decoded calldata.
+type DecodedTransaction struct {
+	// ChainID represents the chain the transaction was sent to.
+	ChainID *big.Int `json:"chain_id"`
+
+	// Hash represents the hash of the transaction.
+	Hash common.Hash `json:"hash"`
+
+	// Type represents the transaction envelope type (legacy, access list, dynamic fee).
+	Type uint8 `json:"type"`
+
+	// Pending is set when the transaction is not yet included in the block.
+	Pending bool `json:"pending"`
+
+	// From represents the sender of the transaction.
+	From common.Address `json:"from"`
+
+	// To represents the recipient of the transaction. It is nil for contract creation transactions.
+	To *common.Address `json:"to"`
+
+	// Nonce represents the sender nonce of the transaction.
+	Nonce uint64 `json:"nonce"`
+
+	// Value represents the amount of wei transferred with the transaction.
+	Value *big.Int `json:"value"`
+
+	// Gas represents the gas limit of the transaction.
+	Gas uint64 `json:"gas"`
+
+	// GasPrice represents the gas price of the transaction.
+	GasPrice *big.Int `json:"gas_price"`
+
+	// GasTipCap represents the max priority fee per gas of the transaction.
+	GasTipCap *big.Int `json:"gas_tip_cap"`
+
+	// GasFeeCap represents the max fee per gas of the transaction.
+	GasFeeCap *big.Int `json:"gas_fee_cap"`
+
+	// Input represents the raw calldata of the transaction.
+	Input []byte `json:"input"`
+
+	// IsContractCreation is set when the transaction deploys a new contract.
+	IsContractCreation bool `json:"is_contract_creation"`
+
+	// Method represents the decoded method. It is nil when calldata could not be decoded.
+	Method *DecodedMethod `json:"method,omitempty"`
+}
+
+// DecodedEvent represents the event resolved from the log topics.
+type DecodedEvent struct {
+	// Hash is the event topic hash (topics[0]).
+	Hash common.Hash `json:"hash"`
+
+	// Name is the name of the event.
+	Name string `json:"name"`
+
+	// Signature is the canonical event signature, e.g. Transfer(address,address,uint256).
+	Signature string `json:"signature"`
+
+	// IsPartial is set when the event was resolved through signature database instead of the verified contract ABI.
+	IsPartial bool `json:"is_partial"`
+
+	// Arguments holds decoded indexed and non-indexed arguments in ABI order.
+	Arguments []abis.Value `json:"arguments"`
+}
+
+// DecodedLog represents a log together with its decoded event.
+type DecodedLog struct {
+	// Address represents the contract that emitted the log.
+	Address common.Address `json:"address"`
+
+	// BlockNumber represents the block in which the log was emitted.
+	BlockNumber uint64 `json:"block_number"`
+
+	// BlockHash represents the hash of the block in which the log was emitted.
+	BlockHash common.Hash `json:"block_hash"`
+
+	// TransactionHash represents the hash of the transaction that emitted the log.
+	TransactionHash common.Hash `json:"transaction_hash"`
+
+	// TransactionIndex represents the index of the transaction in the block.
+	TransactionIndex uint `json:"transaction_index"`
+
+	// LogIndex represents the index of the log in the block.
+	LogIndex uint `json:"log_index"`
+
+	// Topics represents raw log topics.
+	Topics []common.Hash `json:"topics"`
+
+	// Data represents raw non-indexed log data.
+	Data []byte `json:"data"`
+
+	// Decoded is set when the log was successfully decoded against the stored event.
+	Decoded bool `json:"decoded"`
+
+	// Error holds the reason why the log could not be decoded.
+	Error string `json:"error,omitempty"`
+
+	// Event represents the decoded event. It is nil when log could not be decoded.
+	Event *DecodedEvent `json:"event,omitempty"`
+}
+
+// DecodedReceipt represents a transaction receipt together with its decoded logs.
+type DecodedReceipt struct {
+	// TransactionHash represents the hash of the transaction.
+	TransactionHash common.Hash `json:"transaction_hash"`
+
+	// TransactionIndex represents the index of the transaction in the block.
+	TransactionIndex uint `json:"transaction_index"`
+
+	// BlockHash represents the hash of the block in which the transaction was included.
+	BlockHash common.Hash `json:"block_hash"`
+
+	// BlockNumber represents the block in which the transaction was included.
+	BlockNumber uint64 `json:"block_number"`
+
+	// Type represents the transaction envelope type.
+	Type uint8 `json:"type"`
+
+	// Status represents the execution status of the transaction (1 success, 0 failure).
+	Status uint64 `json:"status"`
+
+	// GasUsed represents the amount of gas used by the transaction alone.
+	GasUsed uint64 `json:"gas_used"`
+
+	// CumulativeGasUsed represents the amount of gas used in the block up to and including this transaction.
+	CumulativeGasUsed uint64 `json:"cumulative_gas_used"`
+
+	// EffectiveGasPrice represents the actual price per gas paid by the transaction.
+	EffectiveGasPrice *big.Int `json:"effective_gas_price"`
+
+	// ContractAddress represents the address of the created contract. It is nil if no contract was created.
+	ContractAddress *common.Address `json:"contract_address,omitempty"`
+
+	// Logs represents decoded logs emitted by the transaction.
+	Logs []*DecodedLog `json:"logs"`
+}
+
+// DecodedCall represents a single (internal) call from the call tree with its decoded input and output.
+type DecodedCall struct {
+	// Type represents the call type, e.g. CALL, STATICCALL, DELEGATECALL, CREATE, CREATE2.
+	Type string `json:"type"`
+
+	// From represents the caller.
+	From common.Address `json:"from"`
+
+	// To represents the callee. For DELEGATECALL this is the address whose code was executed.
+	To *common.Address `json:"to,omitempty"`
+
+	// Value represents the amount of wei transferred with the call.
+	Value *big.Int `json:"value,omitempty"`
+
+	// Gas represents the gas provided to the call.
+	Gas uint64 `json:"gas"`
+
+	// GasUsed represents the gas used by the call.
+	GasUsed uint64 `json:"gas_used"`
+
+	// Input represents raw call input.
+	Input []byte `json:"input"`
+
+	// Output represents raw call output.
+	Output []byte `json:"output,omitempty"`
+
+	// Error represents the error returned by the call, if any.
+	Error string `json:"error,omitempty"`
+
+	// RevertReason represents the revert reason reported by the node, if any.
+	RevertReason string `json:"revert_reason,omitempty"`
+
+	// Method represents the decoded method with its input arguments. It is nil when input could not be decoded.
+	Method *DecodedMethod `json:"method,omitempty"`
+
+	// Outputs represents decoded return values of the method.
+	Outputs []abis.Value `json:"outputs,omitempty"`
+
+	// Revert represents decoded revert data of the failed call.
+	Revert *abis.Revert `json:"revert,omitempty"`
+
+	// Calls represents nested calls made by this call.
+	Calls []*DecodedCall `json:"calls,omitempty"`
+}
+
+// DecodedTrace represents the decoded call tree of a single transaction.
+type DecodedTrace struct {
+	// TransactionHash represents the hash of the traced transaction.
+	TransactionHash common.Hash `json:"transaction_hash"`
+
+	// Error represents the tracer error for this transaction, if any.
+	Error string `json:"error,omitempty"`
+
+	// Call represents the top level call of the transaction.
+	Call *DecodedCall `json:"call,omitempty"`
+}
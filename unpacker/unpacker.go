@@ -10,6 +10,7 @@ import (
 	"github.com/txpull/unpack/abis"
 	"github.com/txpull/unpack/clients"
 	"github.com/txpull/unpack/contracts"
+	"github.com/txpull/unpack/db"
 	"github.com/txpull/unpack/readers"
 	"github.com/txpull/unpack/scanners"
 )
@@ -23,12 +24,21 @@ type Unpacker struct {
 	reader          *readers.Manager
 	bitquery        *scanners.BitQueryProvider
 	ethClient       *clients.EthClient
-	bscscan         *scanners.BscScanProvider
-	contractDecoder *contracts.Decoder
+	etherscan       *scanners.EtherscanProviders
+	contractDecoder addressDecoder
+	clickhouseDb    *db.ClickHouse
+}
+
+// addressDecoder resolves the contract, together with its ABI, deployed at the address.
+// It is implemented by contracts.Decoder.
+type addressDecoder interface {
+	DecodeByAddress(chainId *big.Int, addr common.Address, abi *abis.Decoder) (*contracts.ContractResponse, error)
+	DecodeAbiByAddress(chainId *big.Int, addr common.Address) (*contracts.ContractResponse, error)
 }
 
 type UnpackerOption func(*Unpacker)
 
+// WithBitQuery sets the optional BitQuery provider used to speed up the contract creation lookups.
 func WithBitQuery(bq *scanners.BitQueryProvider) UnpackerOption {
 	return func(c *Unpacker) {
 		c.bitquery = bq
@@ -47,9 +57,9 @@ func WithReaderManager(client *readers.Manager) UnpackerOption {
 	}
 }
 
-func WithBscScan(client *scanners.BscScanProvider) UnpackerOption {
+func WithEtherscan(client *scanners.EtherscanProviders) UnpackerOption {
 	return func(w *Unpacker) {
-		w.bscscan = client
+		w.etherscan = client
 	}
 }
 
@@ -59,6 +69,13 @@ func WithSourcify(client *sourcify.Client) UnpackerOption {
 	}
 }
 
+// WithClickHouseDb sets the clickhouse database used to store resolved proxy to implementation links.
+func WithClickHouseDb(client *db.ClickHouse) UnpackerOption {
+	return func(w *Unpacker) {
+		w.clickhouseDb = client
+	}
+}
+
 func NewUnpacker(ctx context.Context, opts ...UnpackerOption) (*Unpacker, error) {
 	unpacker := &Unpacker{
 		ctx: ctx,
@@ -77,10 +94,6 @@ func NewUnpacker(ctx context.Context, opts ...UnpackerOption) (*Unpacker, error)
 		return nil, errors.New("reader manager is required")
 	}
 
-	if unpacker.bitquery == nil {
-		return nil, errors.New("bitquery client is required")
-	}
-
 	// Setup the decoders for future use
 	if err := unpacker.setupDecoders(); err != nil {
 		return nil, err
@@ -98,22 +111,6 @@ func (u *Unpacker) UnpackContract(chainId *big.Int, addr common.Address, abi *ab
 	return contract, nil
 }
 
-func (u *Unpacker) UnpackTransaction(chainId *big.Int, txHash common.Hash) error {
-	return nil
-}
-
-func (u *Unpacker) UnpackReceipt(blockNumber uint64) error {
-	return nil
-}
-
-func (u *Unpacker) UnpackLogs(blockNumber uint64) error {
-	return nil
-}
-
-func (u *Unpacker) UnpackTrace(blockNumber uint64) error {
-	return nil
-}
-
 func (u *Unpacker) setupDecoders() error {
 	decoder, err := contracts.NewDecoder(
 		u.ctx,
@@ -121,7 +118,8 @@ func (u *Unpacker) setupDecoders() error {
 		contracts.WithSourcify(u.sourcifyClient),
 		contracts.WithBitQuery(u.bitquery),
 		contracts.WithEthClient(u.ethClient),
-		contracts.WithBscScan(u.bscscan),
+		contracts.WithEtherscan(u.etherscan),
+		contracts.WithClickHouseDb(u.clickhouseDb),
 	)
 	if err != nil {
 		return err
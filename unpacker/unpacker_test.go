@@ -59,7 +59,7 @@ func TestGenericUnpacker(t *testing.T) {
 	tAssert.NoError(err)
 	tAssert.NotNil(client)
 
-	// BitQuery is used to fetch contract block and transaction information if necessary.
+	// BitQuery is optionally used to speed up contract block and transaction lookups.
 	bitquery := scanners.NewBitQueryProvider(
 		os.Getenv("BITQUERY_API_URL"),
 		os.Getenv("BITQUERY_API_KEY"),
@@ -0,0 +1,181 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/txpull/unpack/types"
+)
+
+// ImmutableReferences maps the AST id of the immutable variable onto its positions within the runtime
+// bytecode, the way solc and Sourcify report them.
+type ImmutableReferences map[string][]ImmutableReference
+
+// List returns positions of every immutable variable.
+func (r ImmutableReferences) List() []ImmutableReference {
+	toReturn := make([]ImmutableReference, 0, len(r))
+	for _, references := range r {
+		toReturn = append(toReturn, references...)
+	}
+
+	return toReturn
+}
+
+// CompiledContract represents the compiler output the on-chain runtime bytecode is verified against.
+type CompiledContract struct {
+	// RuntimeBytecode represents the compiled runtime (deployed) bytecode, immutable values zeroed.
+	RuntimeBytecode []byte
+
+	// ImmutableReferences represents positions of the immutable variable values within RuntimeBytecode.
+	ImmutableReferences []ImmutableReference
+}
+
+// Compiler compiles the verified sources into the runtime bytecode of the named contract.
+type Compiler interface {
+	Compile(ctx context.Context, source *types.ContractSource, compilerVersion string, contractName string) (*CompiledContract, error)
+}
+
+// Solc compiles Solidity sources with the solc binary matching the compiler version the contract was verified
+// with. Binaries are looked up in the directory as solc-<version>, e.g. solc-0.8.19 for v0.8.19+commit.7dd6d404.
+type Solc struct {
+	binariesPath string
+}
+
+func NewSolc(binariesPath string) *Solc {
+	return &Solc{binariesPath: binariesPath}
+}
+
+// solcInput represents the solc standard JSON input.
+type solcInput struct {
+	Language string                     `json:"language"`
+	Sources  map[string]solcSource      `json:"sources"`
+	Settings map[string]json.RawMessage `json:"settings"`
+}
+
+type solcSource struct {
+	Content string `json:"content"`
+}
+
+// solcOutput represents the subset of the solc standard JSON output we are interested in.
+type solcOutput struct {
+	Errors []struct {
+		Severity         string `json:"severity"`
+		FormattedMessage string `json:"formattedMessage"`
+	} `json:"errors"`
+	Contracts map[string]map[string]struct {
+		EVM struct {
+			DeployedBytecode struct {
+				Object              string              `json:"object"`
+				ImmutableReferences ImmutableReferences `json:"immutableReferences"`
+			} `json:"deployedBytecode"`
+		} `json:"evm"`
+	} `json:"contracts"`
+}
+
+// Compile compiles the sources with the settings they were verified with and returns the runtime bytecode
+// of the named contract. Only the runtime bytecode is requested from the compiler.
+func (s *Solc) Compile(ctx context.Context, source *types.ContractSource, compilerVersion string, contractName string) (*CompiledContract, error) {
+	if source.Language != types.ContractLanguageSolidity {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, source.Language)
+	}
+
+	binary, err := s.binary(compilerVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := s.input(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, "--standard-json")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run solc: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output solcOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal solc output: %w", err)
+	}
+
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			return nil, fmt.Errorf("failed to compile sources: %s", e.FormattedMessage)
+		}
+	}
+
+	// Contract name is unique within the verified sources in practice, paths are sorted to stay deterministic.
+	paths := make([]string, 0, len(output.Contracts))
+	for path := range output.Contracts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		contract, ok := output.Contracts[path][contractName]
+		if !ok {
+			continue
+		}
+
+		return &CompiledContract{
+			RuntimeBytecode:     common.FromHex(contract.EVM.DeployedBytecode.Object),
+			ImmutableReferences: contract.EVM.DeployedBytecode.ImmutableReferences.List(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrContractNotCompiled, contractName)
+}
+
+// binary returns the path of the solc binary for the compiler version reported by the explorer or metadata.
+func (s *Solc) binary(compilerVersion string) (string, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(compilerVersion), "v")
+	if index := strings.Index(version, "+"); index >= 0 {
+		version = version[:index]
+	}
+
+	binary := filepath.Join(s.binariesPath, "solc-"+version)
+	if _, err := os.Stat(binary); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrCompilerNotFound, binary)
+	}
+
+	return binary, nil
+}
+
+// input builds the standard JSON input out of the source tree, asking only for the runtime bytecode.
+func (s *Solc) input(source *types.ContractSource) ([]byte, error) {
+	toReturn := solcInput{
+		Language: "Solidity",
+		Sources:  make(map[string]solcSource, len(source.Sources)),
+		Settings: make(map[string]json.RawMessage),
+	}
+
+	for path, content := range source.Sources {
+		toReturn.Sources[path] = solcSource{Content: content}
+	}
+
+	if len(source.Settings) > 0 {
+		if err := json.Unmarshal([]byte(source.Settings), &toReturn.Settings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal compiler settings: %w", err)
+		}
+	}
+
+	toReturn.Settings["outputSelection"] = json.RawMessage(
+		`{"*":{"*":["evm.deployedBytecode.object","evm.deployedBytecode.immutableReferences"]}}`,
+	)
+
+	return json.Marshal(toReturn)
+}
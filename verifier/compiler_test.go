@@ -0,0 +1,66 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/types"
+)
+
+const solcTestOutput = `{
+	"errors": [{"severity": "warning", "formattedMessage": "Warning: SPDX license identifier not provided"}],
+	"contracts": {
+		"contracts/Token.sol": {
+			"Token": {"evm": {"deployedBytecode": {
+				"object": "6080604052600080fd",
+				"immutableReferences": {"12": [{"start": 2, "length": 32}]}
+			}}}
+		}
+	}
+}`
+
+func TestSolc_Compile(t *testing.T) {
+	tAssert := assert.New(t)
+
+	// Fake solc keeps the standard JSON input next to itself and prints the canned output.
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncat > \"$(dirname \"$0\")/input.json\"\ncat \"$(dirname \"$0\")/output.json\"\n"
+	tAssert.NoError(os.WriteFile(filepath.Join(dir, "solc-0.8.19"), []byte(script), 0700))
+	tAssert.NoError(os.WriteFile(filepath.Join(dir, "output.json"), []byte(solcTestOutput), 0600))
+
+	source := &types.ContractSource{
+		Language: types.ContractLanguageSolidity,
+		Sources:  map[string]string{"contracts/Token.sol": "contract Token {}"},
+		Settings: `{"optimizer":{"enabled":true,"runs":200}}`,
+	}
+
+	solc := NewSolc(dir)
+
+	compiled, err := solc.Compile(context.TODO(), source, "v0.8.19+commit.7dd6d404", "Token")
+	tAssert.NoError(err)
+	tAssert.Equal(common.FromHex("0x6080604052600080fd"), compiled.RuntimeBytecode)
+	tAssert.Equal([]ImmutableReference{{Start: 2, Length: 32}}, compiled.ImmutableReferences)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "input.json"))
+	tAssert.NoError(err)
+
+	var input solcInput
+	tAssert.NoError(json.Unmarshal(raw, &input))
+	tAssert.Equal("contract Token {}", input.Sources["contracts/Token.sol"].Content)
+	tAssert.JSONEq(`{"enabled":true,"runs":200}`, string(input.Settings["optimizer"]))
+	tAssert.Contains(string(input.Settings["outputSelection"]), "evm.deployedBytecode.object")
+
+	_, err = solc.Compile(context.TODO(), source, "v0.8.19+commit.7dd6d404", "Vault")
+	tAssert.ErrorIs(err, ErrContractNotCompiled)
+
+	_, err = solc.Compile(context.TODO(), source, "v0.7.6+commit.7338295f", "Token")
+	tAssert.ErrorIs(err, ErrCompilerNotFound)
+
+	_, err = solc.Compile(context.TODO(), &types.ContractSource{Language: types.ContractLanguageVyper}, "vyper:0.3.7", "Token")
+	tAssert.ErrorIs(err, ErrUnsupportedLanguage)
+}
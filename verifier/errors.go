@@ -0,0 +1,20 @@
+package verifier
+
+import "errors"
+
+var (
+	// ErrNoCode is returned when there is no code deployed at the contract address.
+	ErrNoCode = errors.New("no code at address")
+
+	// ErrNoReferenceBytecode is returned when there is no compiled bytecode to verify against.
+	ErrNoReferenceBytecode = errors.New("no reference bytecode to verify against")
+
+	// ErrUnsupportedLanguage is returned when the compiler does not support the language of the sources.
+	ErrUnsupportedLanguage = errors.New("unsupported source language")
+
+	// ErrCompilerNotFound is returned when the compiler binary of the requested version is not available.
+	ErrCompilerNotFound = errors.New("compiler not found")
+
+	// ErrContractNotCompiled is returned when the compiler output does not contain the requested contract.
+	ErrContractNotCompiled = errors.New("contract not found in compiler output")
+)
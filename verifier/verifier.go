@@ -0,0 +1,220 @@
+// Package verifier verifies contract sources offline by comparing the runtime bytecode deployed on chain
+// with the bytecode the sources compile to. The CBOR metadata trailer
+// and immutable variable values are ignored, so the result is classified the same way Sourcify does it:
+// full (perfect) match, partial match (only the metadata differs) or mismatch.
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/txpull/unpack/clients"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/opcodes"
+	"github.com/txpull/unpack/types"
+)
+
+// MatchType represents the verification result.
+type MatchType string
+
+const (
+	// MatchTypeFull means the bytecode matches, metadata hash included.
+	MatchTypeFull MatchType = "perfect"
+
+	// MatchTypePartial means the bytecode matches except for the metadata hash, e.g. comments or
+	// file paths differ from the ones the contract was deployed with.
+	MatchTypePartial MatchType = "partial"
+
+	// MatchTypeMismatch means the bytecode does not match, the source or ABI is stale or mis-attributed.
+	MatchTypeMismatch MatchType = "mismatch"
+)
+
+// ImmutableReference represents the position of the immutable variable value within the runtime bytecode,
+// as reported by the compiler in evm.deployedBytecode.immutableReferences.
+type ImmutableReference struct {
+	Start  uint64 `json:"start"`
+	Length uint64 `json:"length"`
+}
+
+// Verifier compares the on-chain runtime bytecode of contracts with their compiled bytecode.
+type Verifier struct {
+	ctx       context.Context
+	ethClient *clients.EthClient
+}
+
+// Option defines a function type that applies configurations to a Verifier.
+type Option func(*Verifier)
+
+func WithEthClient(client *clients.EthClient) Option {
+	return func(v *Verifier) {
+		v.ethClient = client
+	}
+}
+
+func NewVerifier(ctx context.Context, opts ...Option) (*Verifier, error) {
+	verifier := &Verifier{ctx: ctx}
+
+	for _, opt := range opts {
+		opt(verifier)
+	}
+
+	if verifier.ethClient == nil {
+		return nil, errors.New("eth client is required")
+	}
+
+	return verifier, nil
+}
+
+// VerifyCompiled compares the runtime bytecode of the contract with the compiled runtime (deployed) bytecode,
+// e.g. the compiler output or the bytecode Sourcify recompiled. Mismatches are flagged in ProcessStatus while
+// VerificationStatus is left to the caller, so that the status reported by Sourcify is kept.
+// Runtime bytecode is fetched from the chain unless already set.
+func (v *Verifier) VerifyCompiled(contract *types.Contract, compiled []byte, immutables []ImmutableReference) (MatchType, error) {
+	if len(compiled) == 0 {
+		return "", ErrNoReferenceBytecode
+	}
+
+	if err := v.populateRuntimeBytecode(contract); err != nil {
+		return "", err
+	}
+
+	match := CompareRuntime(contract.RuntimeBytecode, compiled, immutables)
+	if match == MatchTypeMismatch {
+		contract.ProcessStatus = helpers.CONTRACT_PROCESS_STATUS_MISMATCH
+	}
+
+	return match, nil
+}
+
+func (v *Verifier) populateRuntimeBytecode(contract *types.Contract) error {
+	if len(contract.RuntimeBytecode) == 0 {
+		code, err := helpers.GetBytecode(v.ctx, v.ethClient, contract.Address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get runtime bytecode: %w", err)
+		}
+		contract.RuntimeBytecode = code
+	}
+
+	if len(contract.RuntimeBytecode) == 0 {
+		return ErrNoCode
+	}
+
+	return nil
+}
+
+// CompareRuntime compares the on-chain runtime bytecode with the compiled runtime bytecode. Bytes at the
+// immutable references are ignored. Without references, zeroed PUSH32 operands of the compiled bytecode
+// are treated as immutable placeholders, which is how the compiler leaves them before deployment.
+func CompareRuntime(onchain []byte, compiled []byte, immutables []ImmutableReference) MatchType {
+	if matches(onchain, compiled, immutables) {
+		return MatchTypeFull
+	}
+
+	strippedOnchain, onchainMetadata := opcodes.StripMetadata(onchain)
+	strippedCompiled, compiledMetadata := opcodes.StripMetadata(compiled)
+
+	// Without metadata on either side there is nothing left that is allowed to differ.
+	if onchainMetadata == nil && compiledMetadata == nil {
+		return MatchTypeMismatch
+	}
+
+	if matches(strippedOnchain, strippedCompiled, immutables) {
+		return MatchTypePartial
+	}
+
+	return MatchTypeMismatch
+}
+
+// CompareCreation looks for the on-chain runtime bytecode within the compiled creation bytecode, which carries the
+// runtime code the constructor returns followed by the ABI encoded constructor arguments. Immutable
+// values are assigned by the constructor, hence the zeroed PUSH32 operands are treated as placeholders.
+func CompareCreation(onchain []byte, creation []byte) MatchType {
+	if contains(creation, onchain) {
+		return MatchTypeFull
+	}
+
+	stripped, metadata := opcodes.StripMetadata(onchain)
+	if metadata != nil && contains(creation, stripped) {
+		return MatchTypePartial
+	}
+
+	return MatchTypeMismatch
+}
+
+// contains reports whether the runtime bytecode is found anywhere within the creation bytecode.
+func contains(creation []byte, runtime []byte) bool {
+	if len(runtime) == 0 {
+		return false
+	}
+
+	for offset := 0; offset+len(runtime) <= len(creation); offset++ {
+		// Cheap check of the first instruction before walking the whole candidate.
+		if creation[offset] != runtime[0] {
+			continue
+		}
+
+		if matches(runtime, creation[offset:offset+len(runtime)], nil) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches compares the actual bytecode with the expected one byte by byte, skipping immutable values.
+func matches(actual []byte, expected []byte, immutables []ImmutableReference) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+
+	if bytes.Equal(actual, expected) {
+		return true
+	}
+
+	// Immutable placeholders are zeroed in the expected bytecode, any other difference is the mismatch.
+	// Checking it first keeps the creation bytecode search from disassembling every candidate offset.
+	for i := range expected {
+		if actual[i] != expected[i] && expected[i] != 0 {
+			return false
+		}
+	}
+
+	skip := make([]bool, len(expected))
+	if len(immutables) > 0 {
+		for _, ref := range immutables {
+			for i := ref.Start; i < ref.Start+ref.Length && i < uint64(len(skip)); i++ {
+				skip[i] = true
+			}
+		}
+	} else {
+		for _, instruction := range opcodes.Disassemble(expected) {
+			if instruction.OpCode != opcodes.PUSH32 || instruction.Truncated || !isZero(instruction.Operand) {
+				continue
+			}
+
+			for i := instruction.Offset + 1; i < instruction.Offset+33; i++ {
+				skip[i] = true
+			}
+		}
+	}
+
+	for i := range expected {
+		if !skip[i] && actual[i] != expected[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isZero(operand []byte) bool {
+	for _, b := range operand {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,88 @@
+package verifier
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/txpull/unpack/helpers"
+	"github.com/txpull/unpack/types"
+)
+
+// withMetadata appends the CBOR metadata trailer carrying the IPFS hash filled with the provided byte.
+func withMetadata(code []byte, hash byte) []byte {
+	cbor := []byte{0xa2, 0x64, 'i', 'p', 'f', 's', 0x58, 0x22, 0x12, 0x20}
+	cbor = append(cbor, bytes.Repeat([]byte{hash}, 32)...)
+	cbor = append(cbor, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x13)
+
+	return append(append(append([]byte{}, code...), cbor...), 0x00, byte(len(cbor)))
+}
+
+// withImmutable returns the code reading the immutable value with PUSH32.
+func withImmutable(value byte) []byte {
+	code := common.FromHex("0x608060405260043610")
+	code = append(code, 0x7f)
+	code = append(code, bytes.Repeat([]byte{value}, 32)...)
+	return append(code, common.FromHex("0x60005260206000f3fe")...)
+}
+
+func TestVerifier_CompareRuntime(t *testing.T) {
+	tAssert := assert.New(t)
+
+	compiled := withMetadata(withImmutable(0x00), 0xab)
+
+	tAssert.Equal(MatchTypeFull, CompareRuntime(compiled, compiled, nil))
+	tAssert.Equal(MatchTypeFull, CompareRuntime(withMetadata(withImmutable(0x11), 0xab), compiled, nil))
+	tAssert.Equal(MatchTypePartial, CompareRuntime(withMetadata(withImmutable(0x11), 0xcd), compiled, nil))
+	tAssert.Equal(MatchTypeMismatch, CompareRuntime(withMetadata(common.FromHex("0x6080604052600080fd"), 0xab), compiled, nil))
+
+	// Explicit references take precedence over the zeroed PUSH32 detection.
+	immutables := []ImmutableReference{{Start: 10, Length: 32}}
+	tAssert.Equal(MatchTypeFull, CompareRuntime(withMetadata(withImmutable(0x11), 0xab), compiled, immutables))
+	tAssert.Equal(MatchTypeMismatch, CompareRuntime(withMetadata(withImmutable(0x11), 0xab), compiled, []ImmutableReference{{Start: 0, Length: 4}}))
+
+	// Bytecode without metadata can only match fully.
+	tAssert.Equal(MatchTypeMismatch, CompareRuntime(common.FromHex("0x6080604052600080fd"), common.FromHex("0x6080604052600180fd"), nil))
+}
+
+func TestVerifier_CompareCreation(t *testing.T) {
+	tAssert := assert.New(t)
+
+	constructor := common.FromHex("0x608060405234801561001057600080fd5b5061012380610020600039f3fe")
+	arguments := common.LeftPadBytes([]byte{0x60, 0x01}, 32)
+
+	creation := append(append(append([]byte{}, constructor...), withMetadata(withImmutable(0x00), 0xab)...), arguments...)
+
+	tAssert.Equal(MatchTypeFull, CompareCreation(withMetadata(withImmutable(0x42), 0xab), creation))
+	tAssert.Equal(MatchTypePartial, CompareCreation(withMetadata(withImmutable(0x42), 0xcd), creation))
+	tAssert.Equal(MatchTypeMismatch, CompareCreation(withMetadata(common.FromHex("0x6080604052600080fd"), 0xab), creation))
+	tAssert.Equal(MatchTypeMismatch, CompareCreation(nil, creation))
+}
+
+func TestVerifier_VerifyCompiled(t *testing.T) {
+	tAssert := assert.New(t)
+
+	verifier := &Verifier{}
+
+	// Status reported upstream is kept, mismatches are only flagged in the process status.
+	contract := &types.Contract{
+		RuntimeBytecode:    withMetadata(common.FromHex("0x6080604052600080fd"), 0xab),
+		VerificationStatus: "perfect",
+	}
+
+	match, err := verifier.VerifyCompiled(contract, withMetadata(withImmutable(0x00), 0xab), nil)
+	tAssert.NoError(err)
+	tAssert.Equal(MatchTypeMismatch, match)
+	tAssert.Equal("perfect", contract.VerificationStatus)
+	tAssert.Equal(int8(helpers.CONTRACT_PROCESS_STATUS_MISMATCH), contract.ProcessStatus)
+
+	contract = &types.Contract{RuntimeBytecode: withImmutable(0x42)}
+	match, err = verifier.VerifyCompiled(contract, withImmutable(0x00), nil)
+	tAssert.NoError(err)
+	tAssert.Equal(MatchTypeFull, match)
+	tAssert.Zero(contract.ProcessStatus)
+
+	_, err = verifier.VerifyCompiled(&types.Contract{}, nil, nil)
+	tAssert.ErrorIs(err, ErrNoReferenceBytecode)
+}